@@ -11,6 +11,8 @@ import (
 
 	"github.com/spf13/viper"
 	"go-es/internal/esc"
+	"go-es/internal/notify"
+	"go-es/internal/service/health"
 )
 
 // Config is the main configuration struct.
@@ -27,6 +29,14 @@ type Config struct {
 	Server *server.Config `mapstructure:"server"`
 	// ElasticSearch is the configuration for the Elasticsearch client.
 	ElasticSearch *esc.Config `mapstructure:"elastic_search"`
+	// Notifications configures the change-notification sinks invoked on
+	// index/document mutations. Nil leaves only the default log sink
+	// enabled (see notify.Initialize).
+	Notifications *notify.Config `mapstructure:"notifications"`
+	// HealthCheck configures the periodic cluster health poll backing
+	// /readyz and /livez. Nil disables health checking and leaves /readyz
+	// always-ready.
+	HealthCheck *health.Config `mapstructure:"health_check"`
 }
 
 // LoadConfig reads the configuration from a given file.
@@ -1,9 +1,12 @@
 package errors
 
 import (
+	"errors"
 	"fmt"
 	"github.com/elastic/go-elasticsearch/v8/esapi"
 	"github.com/goccy/go-json"
+	"go-es/internal/esc/retrytransport"
+	"net/http"
 )
 
 type ErrType string
@@ -40,6 +43,18 @@ const (
 	NotFoundError ErrType = "not_found"
 	// UnauthorizedError indicates an unauthorized request.
 	UnauthorizedError ErrType = "unauthorized"
+	// ForbiddenError indicates the caller is authenticated but lacks the
+	// required scope or role.
+	ForbiddenError ErrType = "forbidden"
+	// UnsupportedVersionError indicates the connected cluster is older than
+	// the configured minimum supported version.
+	UnsupportedVersionError ErrType = "unsupported_version"
+	// ClusterUnreachableError indicates that none of the configured cluster
+	// nodes responded to a health check.
+	ClusterUnreachableError ErrType = "cluster_unreachable"
+	// ServiceUnavailableError indicates a node's retrytransport circuit
+	// breaker is open, so the request was short-circuited rather than sent.
+	ServiceUnavailableError ErrType = "service_unavailable"
 )
 
 // ElasticsearchError represents a structured error object returned by the
@@ -76,6 +91,30 @@ func (e ElasticsearchError) As(target any) bool {
 	return true
 }
 
+// NewConnectionError classifies a transport-level failure (an error
+// returned by the go-elasticsearch client before any response was
+// received) into an ElasticsearchError. A tripped retrytransport circuit
+// breaker surfaces as a 503 ServiceUnavailableError, so callers can stop
+// hammering a dead node instead of retrying it themselves; anything else
+// surfaces as the generic 500 ConnectionError.
+func NewConnectionError(err error) ElasticsearchError {
+	var circuitErr *retrytransport.CircuitOpenError
+	if errors.As(err, &circuitErr) {
+		return ElasticsearchError{
+			StatusCode: http.StatusServiceUnavailable,
+			Message:    "elasticsearch node unavailable",
+			Details:    err,
+			Type:       ServiceUnavailableError,
+		}
+	}
+	return ElasticsearchError{
+		StatusCode: http.StatusInternalServerError,
+		Message:    "failed to connect to elastic server",
+		Details:    err,
+		Type:       ConnectionError,
+	}
+}
+
 // ParseElasticsearchError parses the error response from Elasticsearch into a structured error object.
 // It tries to extract the error type and a user-friendly message from the response.
 // If parsing fails, it falls back to a default error message.
@@ -3,14 +3,33 @@ package esc
 import (
 	"fmt"
 	"github.com/elastic/go-elasticsearch/v8"
+	"go-es/internal/esc/retrytransport"
+	"sync/atomic"
+	"time"
 )
 
 // Config is the configuration for the elastic search client.
 type Config struct {
-	Addresses []string `mapstructure:"addresses" validate:"required"` // addresses is the list of addresses for the elastic search server.
-	APIKey    string   `mapstructure:"api_key" validate:"required"`   // apiKey is the api key for the elastic search server.
-	CACert    []byte   `mapstructure:"ca_cert"`                       // caCert is the CA certificate used to verify the identity of the elastic search server.
-	CAPath    string   `mapstructure:"ca_path"`                       // caPath is the path to the CA certificate.
+	Addresses             []string               `mapstructure:"addresses" validate:"required"` // addresses is the list of addresses for the elastic search server.
+	APIKey                string                 `mapstructure:"api_key" validate:"required"`   // apiKey is the api key for the elastic search server.
+	CACert                []byte                 `mapstructure:"ca_cert"`                       // caCert is the CA certificate used to verify the identity of the elastic search server.
+	CAPath                string                 `mapstructure:"ca_path"`                       // caPath is the path to the CA certificate.
+	RetryPolicy           retrytransport.Options `mapstructure:"retry_policy"`                  // retryPolicy configures the retrying transport's backoff and circuit breaker; zero value disables retries.
+	MinVersion            string                 `mapstructure:"min_version"`                   // minVersion is the minimum supported cluster version ("major.minor"); empty falls back to DefaultMinVersion.
+	DiscoverNodesOnStart  bool                   `mapstructure:"discover_nodes_on_start"`       // discoverNodesOnStart sniffs `_nodes/http` once at client construction to seed the node pool beyond the configured addresses.
+	DiscoverNodesInterval time.Duration          `mapstructure:"discover_nodes_interval"`       // discoverNodesInterval re-sniffs `_nodes/http` on this period; zero disables periodic sniffing. It has effect only when healthcheckInterval is also set.
+	HealthcheckInterval   time.Duration          `mapstructure:"healthcheck_interval"`          // healthcheckInterval pings every known node with `HEAD /` on this period; zero disables background health checking.
+}
+
+// activeTransport holds the retrying transport wired into the most recent
+// NewClient call, so a /metrics endpoint can report its counters without
+// holding a reference to the client's constructor.
+var activeTransport atomic.Pointer[retrytransport.RoundTripper]
+
+// Transport returns the retrying transport wired into the most recent
+// NewClient call, or nil if retries are disabled (zero-value RetryPolicy).
+func Transport() *retrytransport.RoundTripper {
+	return activeTransport.Load()
 }
 
 // NewClient returns a new elasticsearch client based on the configuration.
@@ -28,6 +47,17 @@ func NewClient(cfg *Config) (*elasticsearch.Client, error) {
 	if cfg.CACert != nil {
 		conf.CACert = cfg.CACert
 	}
+	if cfg.RetryPolicy.MaxRetries > 0 {
+		rt := retrytransport.New(nil, cfg.RetryPolicy)
+		conf.Transport = rt
+		activeTransport.Store(rt)
+	}
+
+	if cfg.HealthcheckInterval > 0 || cfg.DiscoverNodesOnStart {
+		if err := initNodePool(cfg); err != nil {
+			return nil, err
+		}
+	}
 
 	client, err := elasticsearch.NewClient(conf)
 	if err != nil {
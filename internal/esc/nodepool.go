@@ -0,0 +1,203 @@
+package esc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/rs/zerolog/log"
+	"go-es/internal/errors"
+)
+
+// NodePool tracks the cluster's known HTTP addresses and which of them are
+// currently reachable. It is refreshed by periodic sniffing (`_nodes/http`)
+// and health checks (`HEAD /`) and is reported through LiveNodes. The
+// client's transport does not consult it: requests still go to the
+// configured addresses whatever their last health-check result.
+type NodePool struct {
+	mu     sync.RWMutex
+	nodes  map[string]bool // address -> reachable
+	client *http.Client
+}
+
+// newNodePool seeds a NodePool from a client's configured addresses,
+// initially assumed reachable until the first health check runs.
+func newNodePool(addresses []string) *NodePool {
+	nodes := make(map[string]bool, len(addresses))
+	for _, addr := range addresses {
+		nodes[addr] = true
+	}
+	return &NodePool{nodes: nodes, client: &http.Client{Timeout: 5 * time.Second}}
+}
+
+// LiveNodes returns the addresses that passed the most recent health check,
+// sorted for stable output.
+func (p *NodePool) LiveNodes() []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	live := make([]string, 0, len(p.nodes))
+	for addr, reachable := range p.nodes {
+		if reachable {
+			live = append(live, addr)
+		}
+	}
+	sort.Strings(live)
+	return live
+}
+
+// healthcheck issues a HEAD / against every known node and records whether
+// it responded without error.
+func (p *NodePool) healthcheck(ctx context.Context) {
+	p.mu.RLock()
+	addrs := make([]string, 0, len(p.nodes))
+	for addr := range p.nodes {
+		addrs = append(addrs, addr)
+	}
+	p.mu.RUnlock()
+
+	for _, addr := range addrs {
+		reachable := p.ping(ctx, addr)
+		p.mu.Lock()
+		p.nodes[addr] = reachable
+		p.mu.Unlock()
+	}
+}
+
+func (p *NodePool) ping(ctx context.Context, addr string) bool {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, addr, nil)
+	if err != nil {
+		return false
+	}
+	res, err := p.client.Do(req)
+	if err != nil {
+		return false
+	}
+	res.Body.Close()
+	return res.StatusCode < http.StatusInternalServerError
+}
+
+// sniff queries `_nodes/http` on the first live node and merges any
+// previously-unknown publish addresses into the pool.
+func (p *NodePool) sniff(ctx context.Context) {
+	live := p.LiveNodes()
+	if len(live) == 0 {
+		return
+	}
+
+	scheme := "http://"
+	if strings.HasPrefix(live[0], "https://") {
+		scheme = "https://"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, live[0]+"/_nodes/http", nil)
+	if err != nil {
+		return
+	}
+	res, err := p.client.Do(req)
+	if err != nil {
+		log.Warn().Err(err).Str("node", live[0]).Msg("failed to sniff cluster nodes")
+		return
+	}
+	defer res.Body.Close()
+
+	var body struct {
+		Nodes map[string]struct {
+			HTTP struct {
+				PublishAddress string `json:"publish_address"`
+			} `json:"http"`
+		} `json:"nodes"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		log.Warn().Err(err).Msg("failed to decode _nodes/http response")
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, n := range body.Nodes {
+		if n.HTTP.PublishAddress == "" {
+			continue
+		}
+		addr := scheme + n.HTTP.PublishAddress
+		if _, known := p.nodes[addr]; !known {
+			p.nodes[addr] = true
+		}
+	}
+}
+
+// run periodically health-checks (and, if discoverInterval > 0, re-sniffs)
+// the pool until ctx is cancelled.
+func (p *NodePool) run(ctx context.Context, healthcheckInterval, discoverInterval time.Duration) {
+	healthTicker := time.NewTicker(healthcheckInterval)
+	defer healthTicker.Stop()
+
+	var sniffC <-chan time.Time
+	if discoverInterval > 0 {
+		sniffTicker := time.NewTicker(discoverInterval)
+		defer sniffTicker.Stop()
+		sniffC = sniffTicker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-healthTicker.C:
+			p.healthcheck(ctx)
+		case <-sniffC:
+			p.sniff(ctx)
+			p.healthcheck(ctx)
+		}
+	}
+}
+
+// activeNodePool holds the pool populated by the most recent NewClient call,
+// so LiveNodes can report it without a reference to the client.
+var activeNodePool atomic.Pointer[NodePool]
+
+// LiveNodes returns the addresses of reachable cluster nodes known to the
+// most recently constructed client, or nil if node discovery/health
+// checking was never enabled.
+func LiveNodes() []string {
+	np := activeNodePool.Load()
+	if np == nil {
+		return nil
+	}
+	return np.LiveNodes()
+}
+
+// initNodePool health-checks cfg's configured addresses (sniffing them first
+// if cfg.DiscoverNodesOnStart is set), fails fast if none respond, and - when
+// cfg.HealthcheckInterval is set - starts a background goroutine to keep the
+// pool current for the lifetime of the process.
+func initNodePool(cfg *Config) error {
+	pool := newNodePool(cfg.Addresses)
+	pool.healthcheck(context.Background())
+	if cfg.DiscoverNodesOnStart {
+		pool.sniff(context.Background())
+		pool.healthcheck(context.Background())
+	}
+
+	live := pool.LiveNodes()
+	if len(live) == 0 {
+		return errors.ElasticsearchError{
+			StatusCode: http.StatusServiceUnavailable,
+			Message:    "no reachable elasticsearch nodes",
+			Details:    fmt.Errorf("all %d configured node(s) failed health check", len(cfg.Addresses)),
+			Type:       errors.ClusterUnreachableError,
+		}
+	}
+
+	activeNodePool.Store(pool)
+	if cfg.HealthcheckInterval > 0 {
+		go pool.run(context.Background(), cfg.HealthcheckInterval, cfg.DiscoverNodesInterval)
+	}
+	return nil
+}
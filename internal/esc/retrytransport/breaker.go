@@ -0,0 +1,125 @@
+package retrytransport
+
+import (
+	"sync"
+	"time"
+)
+
+// breakerState is the state of a circuit breaker for a single node.
+type breakerState int
+
+const (
+	stateClosed breakerState = iota
+	stateOpen
+	stateHalfOpen
+)
+
+// breaker is a simple per-node circuit breaker: it opens after
+// FailureThreshold consecutive failures, stays open for CooldownPeriod, then
+// allows a single half-open probe before closing again on success.
+type breaker struct {
+	mu sync.Mutex
+
+	threshold int
+	cooldown  time.Duration
+
+	state       breakerState
+	failures    int
+	openedAt    time.Time
+	justTripped bool
+}
+
+func newBreaker(threshold int, cooldown time.Duration) *breaker {
+	if threshold <= 0 {
+		threshold = 5
+	}
+	if cooldown <= 0 {
+		cooldown = 30 * time.Second
+	}
+	return &breaker{threshold: threshold, cooldown: cooldown}
+}
+
+// breakerFor returns the breaker for the given host, creating one on first
+// use.
+func (t *RoundTripper) breakerFor(host string) *breaker {
+	if v, ok := t.breakers.Load(host); ok {
+		return v.(*breaker)
+	}
+	br := newBreaker(t.opts.FailureThreshold, t.opts.CooldownPeriod)
+	actual, _ := t.breakers.LoadOrStore(host, br)
+	return actual.(*breaker)
+}
+
+// allow reports whether a request may proceed against this node. An open
+// breaker rejects requests until the cooldown elapses, at which point it
+// moves to half-open and allows exactly one probe through.
+func (b *breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case stateOpen:
+		if time.Since(b.openedAt) >= b.cooldown {
+			b.state = stateHalfOpen
+			return true
+		}
+		return false
+	default:
+		return true
+	}
+}
+
+// recordFailure registers a failed attempt, opening the breaker once
+// consecutive failures reach the threshold.
+func (b *breaker) recordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.justTripped = false
+	if b.state == stateHalfOpen {
+		b.trip()
+		return
+	}
+	b.failures++
+	if b.failures >= b.threshold {
+		b.trip()
+	}
+}
+
+func (b *breaker) trip() {
+	b.state = stateOpen
+	b.openedAt = time.Now()
+	b.failures = 0
+	b.justTripped = true
+}
+
+// recordSuccess resets the breaker to closed.
+func (b *breaker) recordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = stateClosed
+	b.failures = 0
+	b.justTripped = false
+}
+
+// tripped reports whether the most recent recordFailure call opened the
+// breaker, for metrics purposes.
+func (b *breaker) tripped() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.justTripped
+}
+
+// isOpen reports whether the breaker is currently rejecting requests, for
+// metrics purposes. A breaker whose cooldown has elapsed is reported as
+// closed even though allow() hasn't yet flipped its state to half-open,
+// since the node would already be let through on the next attempt.
+func (b *breaker) isOpen() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == stateOpen && time.Since(b.openedAt) >= b.cooldown {
+		return false
+	}
+	return b.state == stateOpen
+}
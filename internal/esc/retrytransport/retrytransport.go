@@ -0,0 +1,444 @@
+// Package retrytransport provides an http.RoundTripper that retries
+// transient Elasticsearch transport failures with exponential backoff and
+// trips a per-node circuit breaker when a node keeps failing.
+package retrytransport
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/rs/zerolog"
+)
+
+// Options configures a RoundTripper.
+type Options struct {
+	MaxRetries     int           // maximum number of retry attempts per request
+	BaseDelay      time.Duration // initial backoff delay
+	MaxDelay       time.Duration // cap on any single backoff delay
+	MaxElapsedTime time.Duration // total time budget across all retries
+	// FailureThreshold is the number of consecutive failures against a node
+	// before its circuit opens.
+	FailureThreshold int
+	// CooldownPeriod is how long a tripped breaker stays open before
+	// allowing a half-open probe.
+	CooldownPeriod time.Duration
+	// RetryOnStatus lists the HTTP statuses eligible for retry. Empty uses
+	// defaultRetryOnStatus (429, 502, 503, 504).
+	RetryOnStatus []int
+}
+
+// DefaultOptions returns sane defaults for Options.
+func DefaultOptions() Options {
+	return Options{
+		MaxRetries:       3,
+		BaseDelay:        250 * time.Millisecond,
+		MaxDelay:         10 * time.Second,
+		MaxElapsedTime:   30 * time.Second,
+		FailureThreshold: 5,
+		CooldownPeriod:   30 * time.Second,
+		RetryOnStatus:    defaultRetryOnStatus,
+	}
+}
+
+// defaultRetryOnStatus is used whenever Options.RetryOnStatus is left empty.
+var defaultRetryOnStatus = []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout}
+
+// NodeMetrics reports the last observed state of a single node, keyed by
+// host in Metrics.Nodes.
+type NodeMetrics struct {
+	LastLatencyMillis int64
+	BreakerOpen       bool
+}
+
+// OpStatusCount reports how many requests for a given Elasticsearch
+// operation (e.g. "_search", "_bulk") completed with a given HTTP status.
+type OpStatusCount struct {
+	Op     string
+	Status int
+	Count  int64
+}
+
+// DurationBucket is one cumulative bucket of a Prometheus-style histogram:
+// Count is the number of requests whose duration was <= LE seconds.
+type DurationBucket struct {
+	LE    float64
+	Count int64
+}
+
+// durationBucketsSeconds are the upper bounds (in seconds) of the request
+// duration histogram, matching Prometheus's own client library defaults.
+var durationBucketsSeconds = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Metrics reports cumulative counters for a RoundTripper, suitable for
+// exposing on a /metrics endpoint.
+type Metrics struct {
+	Attempts     int64
+	Retries      int64
+	BreakerOpens int64
+	LastStatus   int32
+	Nodes        map[string]NodeMetrics
+
+	RequestsByOpStatus []OpStatusCount
+	RetriesByOp        map[string]int64
+	DurationBuckets    []DurationBucket
+	DurationSumSeconds float64
+	DurationCount      int64
+}
+
+// RoundTripper wraps a base http.RoundTripper with retry, backoff, and a
+// per-node circuit breaker.
+type RoundTripper struct {
+	base http.RoundTripper
+	opts Options
+
+	breakers  sync.Map // host -> *breaker
+	latencies sync.Map // host -> *int64 (last observed latency, nanoseconds)
+	metrics   Metrics
+
+	requestsByOpStatus sync.Map // opStatusKey -> *int64
+	retriesByOp        sync.Map // op (string) -> *int64
+	durationBuckets    []int64  // cumulative counts, parallel to durationBucketsSeconds
+	durationSumNanos   int64
+	durationCount      int64
+}
+
+// opStatusKey indexes RoundTripper.requestsByOpStatus.
+type opStatusKey struct {
+	op     string
+	status int
+}
+
+// New wraps base with retry and circuit-breaking behavior. If base is nil,
+// http.DefaultTransport is used.
+func New(base http.RoundTripper, opts Options) *RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &RoundTripper{base: base, opts: opts, durationBuckets: make([]int64, len(durationBucketsSeconds))}
+}
+
+// Metrics returns a snapshot of cumulative retry/breaker counters, plus the
+// last observed latency and breaker state of every node seen so far.
+func (t *RoundTripper) Metrics() Metrics {
+	m := Metrics{
+		Attempts:     atomic.LoadInt64(&t.metrics.Attempts),
+		Retries:      atomic.LoadInt64(&t.metrics.Retries),
+		BreakerOpens: atomic.LoadInt64(&t.metrics.BreakerOpens),
+		LastStatus:   atomic.LoadInt32(&t.metrics.LastStatus),
+		Nodes:        make(map[string]NodeMetrics),
+	}
+
+	t.latencies.Range(func(key, value interface{}) bool {
+		host := key.(string)
+		nodeMetrics := NodeMetrics{LastLatencyMillis: atomic.LoadInt64(value.(*int64)) / int64(time.Millisecond)}
+		if br, ok := t.breakers.Load(host); ok {
+			nodeMetrics.BreakerOpen = br.(*breaker).isOpen()
+		}
+		m.Nodes[host] = nodeMetrics
+		return true
+	})
+
+	m.RetriesByOp = make(map[string]int64)
+	t.retriesByOp.Range(func(key, value interface{}) bool {
+		m.RetriesByOp[key.(string)] = atomic.LoadInt64(value.(*int64))
+		return true
+	})
+
+	t.requestsByOpStatus.Range(func(key, value interface{}) bool {
+		k := key.(opStatusKey)
+		m.RequestsByOpStatus = append(m.RequestsByOpStatus, OpStatusCount{
+			Op:     k.op,
+			Status: k.status,
+			Count:  atomic.LoadInt64(value.(*int64)),
+		})
+		return true
+	})
+
+	m.DurationBuckets = make([]DurationBucket, len(durationBucketsSeconds))
+	for i, le := range durationBucketsSeconds {
+		m.DurationBuckets[i] = DurationBucket{LE: le, Count: atomic.LoadInt64(&t.durationBuckets[i])}
+	}
+	m.DurationSumSeconds = time.Duration(atomic.LoadInt64(&t.durationSumNanos)).Seconds()
+	m.DurationCount = atomic.LoadInt64(&t.durationCount)
+
+	return m
+}
+
+// recordLatency stashes the duration of the most recent attempt against
+// host, for NodeMetrics.
+func (t *RoundTripper) recordLatency(host string, d time.Duration) {
+	v, _ := t.latencies.LoadOrStore(host, new(int64))
+	atomic.StoreInt64(v.(*int64), int64(d))
+}
+
+// recordRequest accounts for one completed RoundTrip call (all attempts
+// included) in the op/status/retry/duration series exposed by Metrics.
+func (t *RoundTripper) recordRequest(op string, status int, retries int, d time.Duration) {
+	key := opStatusKey{op: op, status: status}
+	v, _ := t.requestsByOpStatus.LoadOrStore(key, new(int64))
+	atomic.AddInt64(v.(*int64), 1)
+
+	if retries > 0 {
+		rv, _ := t.retriesByOp.LoadOrStore(op, new(int64))
+		atomic.AddInt64(rv.(*int64), int64(retries))
+	}
+
+	seconds := d.Seconds()
+	for i, le := range durationBucketsSeconds {
+		if seconds <= le {
+			atomic.AddInt64(&t.durationBuckets[i], 1)
+		}
+	}
+	atomic.AddInt64(&t.durationSumNanos, int64(d))
+	atomic.AddInt64(&t.durationCount, 1)
+}
+
+// opAndIndexFromPath extracts the Elasticsearch operation and target
+// index/alias from a request path, e.g. "/products/_search" yields
+// ("_search", "products"). Segments starting with "_" are treated as the
+// operation, the last one found winning (so "/_tasks/id/_cancel" yields
+// "_cancel"); the first non-"_" segment is treated as the index. Paths with
+// no "_"-prefixed segment (a plain document path like "/products/42") yield
+// op "unknown".
+func opAndIndexFromPath(path string) (op string, index string) {
+	op = "unknown"
+	for _, seg := range strings.Split(strings.Trim(path, "/"), "/") {
+		if seg == "" {
+			continue
+		}
+		if strings.HasPrefix(seg, "_") {
+			op = seg
+		} else if index == "" {
+			index = seg
+		}
+	}
+	return op, index
+}
+
+// traceLogger returns the zerolog.Logger attached to req's context by the
+// server's Logger middleware (via zerolog.Logger.WithContext), so the trace
+// line below shares that request's request_id/method/path fields. If none
+// was attached (e.g. a background job built its own request), zerolog.Ctx
+// falls back to a disabled logger and the line is silently dropped.
+func traceLogger(req *http.Request) *zerolog.Logger {
+	return zerolog.Ctx(req.Context())
+}
+
+// RoundTrip implements http.RoundTripper. It delegates to roundTripRetrying
+// for the actual retry/breaker logic, then records the outcome of the
+// overall request (not each individual attempt) in the op/status/retry/
+// duration series exposed by Metrics and, if a
+// zerolog.Logger was attached to req's context (see zerolog.Logger.WithContext,
+// wired in by the server's Logger middleware), emits one structured trace
+// line correlated with that request's request_id.
+func (t *RoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	op, index := opAndIndexFromPath(req.URL.Path)
+	bytesIn := req.ContentLength
+	start := time.Now()
+
+	res, err, retries := t.roundTripRetrying(req)
+
+	duration := time.Since(start)
+	status := 0
+	var bytesOut int64
+	if res != nil {
+		status = res.StatusCode
+		bytesOut = res.ContentLength
+	}
+
+	t.recordRequest(op, status, retries, duration)
+	traceLogger(req).Debug().
+		Str("es_op", op).
+		Str("es_index", index).
+		Str("method", req.Method).
+		Int("status", status).
+		Int("retries", retries).
+		Int64("bytes_in", bytesIn).
+		Int64("bytes_out", bytesOut).
+		Dur("duration", duration).
+		AnErr("error", err).
+		Msg("elasticsearch request")
+
+	return res, err
+}
+
+// roundTripRetrying performs req with retry/backoff/circuit-breaking and
+// reports how many retries it took, so RoundTrip can record and log the
+// outcome of the request as a whole.
+func (t *RoundTripper) roundTripRetrying(req *http.Request) (*http.Response, error, int) {
+	br := t.breakerFor(req.URL.Host)
+	if !br.allow() {
+		return nil, &CircuitOpenError{Host: req.URL.Host}, 0
+	}
+
+	var bodyBytes []byte
+	if req.Body != nil && req.GetBody == nil {
+		// Buffer the body ourselves so it can be replayed on retry.
+		var err error
+		bodyBytes, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err, 0
+		}
+		req.Body = io.NopCloser(bytes.NewReader(bodyBytes))
+	}
+
+	bo := t.newBackOff()
+	deadline := time.Now().Add(t.opts.MaxElapsedTime)
+	var lastErr error
+	for attempt := 0; attempt <= t.opts.MaxRetries; attempt++ {
+		atomic.AddInt64(&t.metrics.Attempts, 1)
+
+		if attempt > 0 {
+			if req.GetBody != nil {
+				rc, err := req.GetBody()
+				if err != nil {
+					return nil, err, attempt
+				}
+				req.Body = rc
+			} else if bodyBytes != nil {
+				req.Body = io.NopCloser(bytes.NewReader(bodyBytes))
+			}
+		}
+
+		start := time.Now()
+		res, err := t.base.RoundTrip(req)
+		t.recordLatency(req.URL.Host, time.Since(start))
+
+		if err == nil {
+			atomic.StoreInt32(&t.metrics.LastStatus, int32(res.StatusCode))
+		}
+
+		if !t.shouldRetry(req, res, err) || attempt == t.opts.MaxRetries || time.Now().After(deadline) {
+			if err == nil && res.StatusCode >= 500 {
+				br.recordFailure()
+			} else if err != nil {
+				br.recordFailure()
+				if br.tripped() {
+					atomic.AddInt64(&t.metrics.BreakerOpens, 1)
+				}
+			} else {
+				br.recordSuccess()
+			}
+			return res, err, attempt
+		}
+
+		br.recordFailure()
+		if br.tripped() {
+			atomic.AddInt64(&t.metrics.BreakerOpens, 1)
+		}
+
+		delay := retryAfter(res)
+		if delay == 0 {
+			delay = bo.NextBackOff()
+		}
+		if res != nil {
+			res.Body.Close()
+		}
+		lastErr = err
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err(), attempt
+		case <-time.After(delay):
+		}
+		atomic.AddInt64(&t.metrics.Retries, 1)
+	}
+
+	return nil, lastErr, t.opts.MaxRetries
+}
+
+// shouldRetry decides whether a failed attempt is eligible for retry: GET/HEAD
+// always, PUT/DELETE only when the body is replayable, and Bulk/Reindex calls
+// regardless of verb, on network errors or a status in t.opts.RetryOnStatus.
+func (t *RoundTripper) shouldRetry(req *http.Request, res *http.Response, err error) bool {
+	if err != nil {
+		return methodAllowsRetry(req)
+	}
+	if res == nil {
+		return false
+	}
+
+	statuses := t.opts.RetryOnStatus
+	if len(statuses) == 0 {
+		statuses = defaultRetryOnStatus
+	}
+	retryable := false
+	for _, s := range statuses {
+		if res.StatusCode == s {
+			retryable = true
+			break
+		}
+	}
+	if !retryable {
+		return false
+	}
+	return methodAllowsRetry(req)
+}
+
+// methodAllowsRetry applies shouldRetry's verb rules to req.
+func methodAllowsRetry(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead:
+		return true
+	case http.MethodPut, http.MethodDelete:
+		return req.GetBody != nil
+	default:
+		return isBulkOrReindex(req)
+	}
+}
+
+// isBulkOrReindex reports whether req targets the _bulk or _reindex APIs,
+// which are explicitly safe to retry even though they're POSTs.
+func isBulkOrReindex(req *http.Request) bool {
+	path := req.URL.Path
+	return containsSuffix(path, "_bulk") || containsSuffix(path, "_reindex")
+}
+
+func containsSuffix(path, suffix string) bool {
+	return len(path) >= len(suffix) && path[len(path)-len(suffix):] == suffix
+}
+
+// retryAfter parses the Retry-After header (seconds form) if present.
+func retryAfter(res *http.Response) time.Duration {
+	if res == nil {
+		return 0
+	}
+	v := res.Header.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	secs, err := strconv.Atoi(v)
+	if err != nil {
+		return 0
+	}
+	return time.Duration(secs) * time.Second
+}
+
+// newBackOff builds a jittered exponential backoff bounded by t.opts.MaxDelay
+// and t.opts.MaxElapsedTime, seeded from t.opts.BaseDelay.
+func (t *RoundTripper) newBackOff() *backoff.ExponentialBackOff {
+	bo := backoff.NewExponentialBackOff()
+	bo.InitialInterval = t.opts.BaseDelay
+	bo.MaxInterval = t.opts.MaxDelay
+	bo.MaxElapsedTime = t.opts.MaxElapsedTime
+	bo.Reset()
+	return bo
+}
+
+// CircuitOpenError is returned when a request is short-circuited because the
+// breaker for its target node is open.
+type CircuitOpenError struct {
+	Host string
+}
+
+func (e *CircuitOpenError) Error() string {
+	return "retrytransport: circuit open for node " + e.Host
+}
@@ -0,0 +1,135 @@
+package retrytransport
+
+import (
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// roundTripFunc adapts a function to http.RoundTripper for building fake
+// transports in tests.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestOpAndIndexFromPath(t *testing.T) {
+	cases := []struct {
+		path      string
+		wantOp    string
+		wantIndex string
+	}{
+		{"/products/_search", "_search", "products"},
+		{"/_tasks/id/_cancel", "_cancel", "id"},
+		{"/products/42", "unknown", "products"},
+		{"/_bulk", "_bulk", ""},
+	}
+	for _, c := range cases {
+		op, index := opAndIndexFromPath(c.path)
+		assert.Equal(t, c.wantOp, op, c.path)
+		assert.Equal(t, c.wantIndex, index, c.path)
+	}
+}
+
+func TestBreakerOpensAfterThreshold(t *testing.T) {
+	br := newBreaker(3, 20*time.Millisecond)
+
+	assert.True(t, br.allow())
+	br.recordFailure()
+	br.recordFailure()
+	assert.True(t, br.allow(), "breaker should stay closed below the threshold")
+	br.recordFailure()
+
+	assert.True(t, br.tripped())
+	assert.False(t, br.allow(), "breaker should reject requests once open")
+
+	time.Sleep(30 * time.Millisecond)
+	assert.True(t, br.allow(), "breaker should allow a half-open probe once the cooldown elapses")
+
+	br.recordSuccess()
+	assert.True(t, br.allow())
+	assert.False(t, br.isOpen())
+}
+
+// TestBreakerConcurrentAccess exercises the breaker's mutex under concurrent
+// callers, the same pattern retrytransport.RoundTripper uses it under (one
+// breaker per host, shared across every in-flight request to that host).
+func TestBreakerConcurrentAccess(t *testing.T) {
+	br := newBreaker(5, 10*time.Millisecond)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if i%2 == 0 {
+				br.recordFailure()
+			} else {
+				br.recordSuccess()
+			}
+			br.allow()
+			br.isOpen()
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestRoundTripRetriesOnTransientStatusThenSucceeds(t *testing.T) {
+	var attempts int64
+	base := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		n := atomic.AddInt64(&attempts, 1)
+		if n < 3 {
+			return &http.Response{StatusCode: http.StatusServiceUnavailable, Body: http.NoBody}, nil
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	})
+
+	opts := DefaultOptions()
+	opts.BaseDelay = time.Millisecond
+	opts.MaxDelay = 2 * time.Millisecond
+	opts.MaxElapsedTime = time.Second
+	rt := New(base, opts)
+
+	req, err := http.NewRequest(http.MethodGet, "http://es-node-1/products/_search", nil)
+	assert.NoError(t, err)
+
+	res, err := rt.RoundTrip(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.EqualValues(t, 3, atomic.LoadInt64(&attempts))
+	assert.EqualValues(t, 2, rt.Metrics().Retries)
+}
+
+// TestRoundTripCircuitOpensAndShortCircuits drives a node's breaker open and
+// confirms subsequent requests are rejected without reaching the base
+// transport at all.
+func TestRoundTripCircuitOpensAndShortCircuits(t *testing.T) {
+	var attempts int64
+	base := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		atomic.AddInt64(&attempts, 1)
+		return &http.Response{StatusCode: http.StatusServiceUnavailable, Body: http.NoBody}, nil
+	})
+
+	opts := DefaultOptions()
+	opts.MaxRetries = 0
+	opts.FailureThreshold = 2
+	opts.CooldownPeriod = time.Minute
+	rt := New(base, opts)
+
+	req := &http.Request{Method: http.MethodGet, URL: &url.URL{Host: "es-node-1", Path: "/products/_search"}}
+
+	for i := 0; i < 2; i++ {
+		_, err := rt.RoundTrip(req)
+		assert.NoError(t, err)
+	}
+	assert.EqualValues(t, 2, atomic.LoadInt64(&attempts))
+
+	_, err := rt.RoundTrip(req)
+	var circuitErr *CircuitOpenError
+	assert.ErrorAs(t, err, &circuitErr)
+	assert.EqualValues(t, 2, atomic.LoadInt64(&attempts), "a tripped breaker must short-circuit before reaching the base transport")
+}
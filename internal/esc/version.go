@@ -0,0 +1,182 @@
+package esc
+
+import (
+	"fmt"
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/goccy/go-json"
+	"github.com/rs/zerolog/log"
+	"go-es/internal/errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// DefaultMinVersion is the minimum supported cluster version used by
+// NewClientWithVersionCheck when cfg.MinVersion is left empty. This service
+// targets Elasticsearch 7.x and 8.x; clusters older than that are refused at
+// startup with a clear log line instead of failing unpredictably on the
+// first incompatible request.
+const DefaultMinVersion = "7.0"
+
+// ClusterVersion is the parsed `version.number` reported by a cluster's root
+// `GET /`, split into major/minor so callers can branch on it (e.g. to omit
+// `include_type_name`, pick a `_reindex` body shape, or choose PIT vs scroll
+// defaults) without re-parsing the string on every call.
+type ClusterVersion struct {
+	Number string
+	Major  int
+	Minor  int
+}
+
+// detectedVersion holds the version detected by the most recent
+// NewClientWithVersionCheck call, so handlers can report it without holding
+// a reference to the client's constructor.
+var detectedVersion atomic.Pointer[ClusterVersion]
+
+// DetectedVersion returns the version detected by the most recent
+// NewClientWithVersionCheck call, or nil if none has run yet.
+func DetectedVersion() *ClusterVersion {
+	return detectedVersion.Load()
+}
+
+// DetectVersion calls the cluster's root `GET /` and parses `version.number`
+// into a ClusterVersion.
+func DetectVersion(client *elasticsearch.Client) (*ClusterVersion, error) {
+	res, err := client.Info()
+	if err != nil {
+		return nil, errors.ElasticsearchError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "failed to connect to elastic server",
+			Details:    err,
+			Type:       errors.ConnectionError,
+		}
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, errors.ParseElasticsearchError(res, "failed to get cluster info")
+	}
+
+	var info struct {
+		Version struct {
+			Number string `json:"number"`
+		} `json:"version"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&info); err != nil {
+		return nil, errors.ElasticsearchError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "failed to decode cluster info response",
+			Details:    err,
+			Type:       errors.DecodeError,
+		}
+	}
+
+	return parseVersion(info.Version.Number)
+}
+
+// parseVersion splits a dotted version string (e.g. "7.17.9") into its
+// major/minor components.
+func parseVersion(number string) (*ClusterVersion, error) {
+	parts := strings.SplitN(number, ".", 3)
+	if len(parts) < 2 {
+		return nil, errors.ElasticsearchError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "failed to parse cluster version",
+			Details:    fmt.Errorf("unrecognized version number %q", number),
+			Type:       errors.ParseError,
+		}
+	}
+
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return nil, errors.ElasticsearchError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "failed to parse cluster version",
+			Details:    err,
+			Type:       errors.ParseError,
+		}
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return nil, errors.ElasticsearchError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "failed to parse cluster version",
+			Details:    err,
+			Type:       errors.ParseError,
+		}
+	}
+
+	return &ClusterVersion{Number: number, Major: major, Minor: minor}, nil
+}
+
+// AtLeast reports whether v is greater than or equal to the dotted
+// "major.minor" string min. Callers that already hold a *ClusterVersion
+// (e.g. via DetectedVersion) use this to gate a specific call rather than
+// relying solely on the startup check in NewClientWithVersionCheck.
+func (v ClusterVersion) AtLeast(min string) (bool, error) {
+	return v.atLeast(min)
+}
+
+// atLeast reports whether v is greater than or equal to the dotted
+// "major.minor" string min.
+func (v ClusterVersion) atLeast(min string) (bool, error) {
+	minVersion, err := parseVersion(min)
+	if err != nil {
+		return false, err
+	}
+	if v.Major != minVersion.Major {
+		return v.Major > minVersion.Major, nil
+	}
+	return v.Minor >= minVersion.Minor, nil
+}
+
+// NewClientWithVersionCheck creates a client exactly as NewClient does, then
+// probes the cluster's root `GET /` and validates the reported version
+// against cfg.MinVersion (a "major.minor" string). An empty MinVersion falls
+// back to DefaultMinVersion rather than skipping the check, so a misconfigured
+// or pre-7.x cluster is refused at startup instead of surfacing as opaque
+// per-request errors later. Clusters below the minimum are rejected with an
+// errors.ElasticsearchError of Type errors.UnsupportedVersionError. A 7.x
+// cluster is still accepted against this v8-compiled client, but logs a
+// deprecation warning since v8-specific features may not be available.
+//
+// The detected version is stashed for DetectedVersion so handlers (e.g. a
+// `GET /_cluster/version` endpoint) can report it without re-probing.
+func NewClientWithVersionCheck(cfg *Config) (*elasticsearch.Client, *ClusterVersion, error) {
+	client, err := NewClient(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	version, err := DetectVersion(client)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	minVersion := cfg.MinVersion
+	if minVersion == "" {
+		minVersion = DefaultMinVersion
+	}
+
+	ok, err := version.atLeast(minVersion)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !ok {
+		log.Error().Str("cluster_version", version.Number).Str("min_version", minVersion).Msg("connected cluster is below the minimum supported version")
+		return nil, nil, errors.ElasticsearchError{
+			StatusCode: http.StatusPreconditionFailed,
+			Message:    fmt.Sprintf("cluster version %s is below the minimum supported version %s", version.Number, minVersion),
+			Details:    fmt.Errorf("unsupported cluster version %s", version.Number),
+			Type:       errors.UnsupportedVersionError,
+		}
+	}
+
+	if version.Major == 7 {
+		log.Warn().Str("cluster_version", version.Number).Msg("connected to an Elasticsearch 7.x cluster with a v8-compiled client; some v8-only behavior may not be available")
+	}
+
+	detectedVersion.Store(version)
+	return client, version, nil
+}
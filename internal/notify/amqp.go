@@ -0,0 +1,70 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"github.com/goccy/go-json"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"sync"
+)
+
+// amqpSink delivers events to an AMQP exchange. The connection is dialed
+// lazily on first Send and reused thereafter. mu guards both the lazy
+// init and the channel itself: amqp091.Channel.PublishWithContext is not
+// safe for concurrent callers, and the dispatcher runs several workers
+// that call Send in parallel (see dispatcher.go's worker pool).
+type amqpSink struct {
+	cfg  *AMQPSinkConfig
+	mu   sync.Mutex
+	conn *amqp.Connection
+	ch   *amqp.Channel
+}
+
+func newAMQPSink(cfg *AMQPSinkConfig) *amqpSink {
+	return &amqpSink{cfg: cfg}
+}
+
+func (s *amqpSink) Name() string { return "amqp" }
+
+func (s *amqpSink) Filter() SinkFilter { return s.cfg.Filter }
+
+// ensureChannel returns a live channel, (re)dialing the connection if
+// needed. Callers must hold s.mu.
+func (s *amqpSink) ensureChannel() (*amqp.Channel, error) {
+	if s.ch != nil && !s.ch.IsClosed() {
+		return s.ch, nil
+	}
+	conn, err := amqp.Dial(s.cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("amqp: failed to connect: %w", err)
+	}
+	ch, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("amqp: failed to open channel: %w", err)
+	}
+	s.conn, s.ch = conn, ch
+	return ch, nil
+}
+
+func (s *amqpSink) Send(ctx context.Context, event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("amqp: failed to marshal event: %w", err)
+	}
+
+	// Hold the lock across the publish itself, not just ensureChannel: a
+	// single amqp091.Channel can't be published on concurrently.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ch, err := s.ensureChannel()
+	if err != nil {
+		return err
+	}
+
+	return ch.PublishWithContext(ctx, s.cfg.Exchange, s.cfg.RoutingKey, false, false, amqp.Publishing{
+		ContentType: "application/json",
+		Body:        payload,
+	})
+}
@@ -0,0 +1,59 @@
+package notify
+
+// Config is the notifications subsystem configuration, loaded under the
+// top-level `notifications` key.
+type Config struct {
+	BufferSize int `mapstructure:"buffer_size"` // size of the dispatch queue before events are dropped
+	Workers    int `mapstructure:"workers"`     // number of worker goroutines delivering to sinks
+
+	Log     *LogSinkConfig     `mapstructure:"log"`
+	AMQP    *AMQPSinkConfig    `mapstructure:"amqp"`
+	Webhook *WebhookSinkConfig `mapstructure:"webhook"`
+	Redis   *RedisSinkConfig   `mapstructure:"redis"`
+	NATS    *NATSSinkConfig    `mapstructure:"nats"`
+}
+
+// LogSinkConfig configures the default zerolog sink. It's on unless
+// explicitly disabled, so a deployment with no notifications config still
+// gets a log line for every mutation.
+type LogSinkConfig struct {
+	Disabled bool       `mapstructure:"disabled"`
+	Filter   SinkFilter `mapstructure:"filter"`
+}
+
+// WebhookSinkConfig configures delivery via an HTTP POST of the JSON event
+// envelope, signed with an HMAC-SHA256 of the body when Secret is set.
+type WebhookSinkConfig struct {
+	Enabled    bool              `mapstructure:"enabled"`
+	URL        string            `mapstructure:"url" validate:"required_if=Enabled true,omitempty,url"`
+	Headers    map[string]string `mapstructure:"headers"`
+	Secret     string            `mapstructure:"secret"`      // HMAC-SHA256 key; when set, signs requests with an X-Signature-256 header
+	MaxRetries int               `mapstructure:"max_retries"` // retries after the first failed delivery attempt before giving up, default 3
+	Filter     SinkFilter        `mapstructure:"filter"`
+}
+
+// AMQPSinkConfig configures delivery to a RabbitMQ (or any AMQP 0.9.1
+// broker) exchange.
+type AMQPSinkConfig struct {
+	Enabled    bool       `mapstructure:"enabled"`
+	URL        string     `mapstructure:"url" validate:"required_if=Enabled true"`
+	Exchange   string     `mapstructure:"exchange"`
+	RoutingKey string     `mapstructure:"routing_key"`
+	Filter     SinkFilter `mapstructure:"filter"`
+}
+
+// RedisSinkConfig configures delivery to a Redis Stream via XADD.
+type RedisSinkConfig struct {
+	Enabled bool       `mapstructure:"enabled"`
+	Addr    string     `mapstructure:"addr" validate:"required_if=Enabled true"`
+	Stream  string     `mapstructure:"stream"`
+	Filter  SinkFilter `mapstructure:"filter"`
+}
+
+// NATSSinkConfig configures delivery to a NATS subject.
+type NATSSinkConfig struct {
+	Enabled bool       `mapstructure:"enabled"`
+	URL     string     `mapstructure:"url" validate:"required_if=Enabled true"`
+	Subject string     `mapstructure:"subject"`
+	Filter  SinkFilter `mapstructure:"filter"`
+}
@@ -0,0 +1,76 @@
+package notify
+
+import (
+	"context"
+	"github.com/rs/zerolog/log"
+	"sync/atomic"
+	"time"
+)
+
+// sinkSendTimeout bounds how long a single sink delivery may take, so one
+// slow sink can't stall the worker pool indefinitely.
+const sinkSendTimeout = 5 * time.Second
+
+// Dispatcher fans events out to a set of Sinks through a bounded worker
+// pool. Publish never blocks the caller: once the queue is full, events are
+// dropped and counted rather than applying backpressure to ES mutations.
+type Dispatcher struct {
+	sinks   []Sink
+	queue   chan Event
+	dropped int64
+}
+
+// NewDispatcher starts workers workers consuming a queue of size
+// bufferSize, each delivering events to every sink whose filter matches.
+func NewDispatcher(sinks []Sink, bufferSize, workers int) *Dispatcher {
+	d := &Dispatcher{
+		sinks: sinks,
+		queue: make(chan Event, bufferSize),
+	}
+	for i := 0; i < workers; i++ {
+		go d.worker()
+	}
+	return d
+}
+
+// Publish enqueues event for delivery. If the queue is full, the oldest
+// queued event is discarded to make room rather than dropping event itself,
+// so the feed always reflects the most recent mutations.
+func (d *Dispatcher) Publish(ctx context.Context, event Event) {
+	for {
+		select {
+		case d.queue <- event:
+			return
+		default:
+			select {
+			case <-d.queue:
+				atomic.AddInt64(&d.dropped, 1)
+				log.Warn().Str("event_type", string(event.EventType)).Str("index", event.Index).
+					Msg("notify: dispatch queue full, dropped oldest event")
+			default:
+			}
+		}
+	}
+}
+
+// Dropped reports the cumulative number of events dropped due to a full
+// queue, for exposing as a metric.
+func (d *Dispatcher) Dropped() int64 {
+	return atomic.LoadInt64(&d.dropped)
+}
+
+func (d *Dispatcher) worker() {
+	for event := range d.queue {
+		for _, sink := range d.sinks {
+			if !sink.Filter().matches(event) {
+				continue
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), sinkSendTimeout)
+			if err := sink.Send(ctx, event); err != nil {
+				log.Error().Err(err).Str("sink", sink.Name()).Str("event_type", string(event.EventType)).
+					Msg("notify: sink delivery failed")
+			}
+			cancel()
+		}
+	}
+}
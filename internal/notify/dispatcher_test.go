@@ -0,0 +1,102 @@
+package notify
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// countingSink records every event it receives, guarding the slice with a
+// mutex since Dispatcher delivers to sinks from multiple worker goroutines.
+type countingSink struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (s *countingSink) Name() string       { return "counting" }
+func (s *countingSink) Filter() SinkFilter { return SinkFilter{} }
+func (s *countingSink) Send(_ context.Context, event Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, event)
+	return nil
+}
+func (s *countingSink) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.events)
+}
+
+// TestDispatcherDeliversConcurrentPublishes publishes from several goroutines
+// at once, the same way concurrent request handlers call notify.Publish, and
+// checks every event is eventually delivered to the sink by the worker pool.
+func TestDispatcherDeliversConcurrentPublishes(t *testing.T) {
+	sink := &countingSink{}
+	d := NewDispatcher([]Sink{sink}, 256, 4)
+
+	const publishers, perPublisher = 8, 25
+	var wg sync.WaitGroup
+	for i := 0; i < publishers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < perPublisher; j++ {
+				d.Publish(context.Background(), Event{EventType: EventDocumentCreated, Index: "products"})
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Eventually(t, func() bool {
+		return sink.count() == publishers*perPublisher
+	}, time.Second, time.Millisecond, "all published events should reach the sink")
+	assert.EqualValues(t, 0, d.Dropped())
+}
+
+// blockingSink never returns from Send until released, used to fill the
+// dispatcher's queue so Publish's drop-oldest behavior under backpressure can
+// be observed deterministically.
+type blockingSink struct {
+	release chan struct{}
+}
+
+func (s *blockingSink) Name() string       { return "blocking" }
+func (s *blockingSink) Filter() SinkFilter { return SinkFilter{} }
+func (s *blockingSink) Send(ctx context.Context, _ Event) error {
+	select {
+	case <-s.release:
+	case <-ctx.Done():
+	}
+	return nil
+}
+
+func TestDispatcherDropsOldestWhenQueueFull(t *testing.T) {
+	sink := &blockingSink{release: make(chan struct{})}
+	defer close(sink.release)
+
+	d := NewDispatcher([]Sink{sink}, 1, 1)
+
+	// The single worker immediately blocks in Send on the first event,
+	// leaving the size-1 queue to fill and then overflow.
+	d.Publish(context.Background(), Event{Index: "first"})
+	time.Sleep(10 * time.Millisecond)
+	d.Publish(context.Background(), Event{Index: "second"})
+	d.Publish(context.Background(), Event{Index: "third"})
+
+	assert.Eventually(t, func() bool {
+		return d.Dropped() > 0
+	}, time.Second, time.Millisecond, "publishing past a full queue should drop the oldest queued event rather than block")
+}
+
+func TestSinkFilterMatches(t *testing.T) {
+	f := SinkFilter{EventTypes: []string{string(EventDocumentCreated)}, IndexPattern: "logs-*"}
+
+	assert.True(t, f.matches(Event{EventType: EventDocumentCreated, Index: "logs-2026"}))
+	assert.False(t, f.matches(Event{EventType: EventDocumentDeleted, Index: "logs-2026"}))
+	assert.False(t, f.matches(Event{EventType: EventDocumentCreated, Index: "products"}))
+
+	assert.True(t, SinkFilter{}.matches(Event{EventType: EventIndexCreated, Index: "anything"}))
+}
@@ -0,0 +1,36 @@
+package notify
+
+import (
+	"context"
+	"github.com/rs/zerolog/log"
+)
+
+// logSink logs every event via zerolog. It's the default sink, so a
+// deployment with no sinks configured at all still has a record of every
+// mutation.
+type logSink struct {
+	filter SinkFilter
+}
+
+func newLogSink(cfg *LogSinkConfig) *logSink {
+	if cfg == nil {
+		return &logSink{}
+	}
+	return &logSink{filter: cfg.Filter}
+}
+
+func (s *logSink) Name() string { return "log" }
+
+func (s *logSink) Filter() SinkFilter { return s.filter }
+
+func (s *logSink) Send(_ context.Context, event Event) error {
+	log.Info().
+		Str("event_type", string(event.EventType)).
+		Str("index", event.Index).
+		Str("document_id", event.DocumentID).
+		Str("request_id", event.RequestID).
+		Str("actor", event.Actor).
+		Time("ts", event.Ts).
+		Msg("notify: event")
+	return nil
+}
@@ -0,0 +1,57 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"github.com/goccy/go-json"
+	"github.com/nats-io/nats.go"
+	"sync"
+)
+
+// natsSink delivers events to a NATS subject. The connection is dialed
+// lazily on first Send and reused thereafter. *nats.Conn is safe for
+// concurrent Publish calls, but the lazy dial/assignment of conn itself is
+// not, so mu guards only ensureConn against the dispatcher's worker pool
+// (see dispatcher.go) calling Send in parallel.
+type natsSink struct {
+	cfg  *NATSSinkConfig
+	mu   sync.Mutex
+	conn *nats.Conn
+}
+
+func newNATSSink(cfg *NATSSinkConfig) *natsSink {
+	return &natsSink{cfg: cfg}
+}
+
+func (s *natsSink) Name() string { return "nats" }
+
+func (s *natsSink) Filter() SinkFilter { return s.cfg.Filter }
+
+func (s *natsSink) ensureConn() (*nats.Conn, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn != nil && s.conn.IsConnected() {
+		return s.conn, nil
+	}
+	conn, err := nats.Connect(s.cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("nats: failed to connect: %w", err)
+	}
+	s.conn = conn
+	return conn, nil
+}
+
+func (s *natsSink) Send(ctx context.Context, event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("nats: failed to marshal event: %w", err)
+	}
+
+	conn, err := s.ensureConn()
+	if err != nil {
+		return err
+	}
+
+	return conn.Publish(s.cfg.Subject, payload)
+}
@@ -0,0 +1,159 @@
+// Package notify publishes bucket-notification-style events whenever an
+// index or document mutation succeeds, so downstream systems can react to
+// Elasticsearch changes without polling.
+package notify
+
+import (
+	"context"
+	"time"
+)
+
+// EventType identifies the kind of mutation that occurred.
+type EventType string
+
+const (
+	EventDocumentCreated EventType = "document.created"
+	EventDocumentUpdated EventType = "document.updated"
+	EventDocumentDeleted EventType = "document.deleted"
+	EventIndexCreated    EventType = "index.created"
+	EventIndexReindexed  EventType = "index.reindexed"
+	EventIndexRolledBack EventType = "index.reindex_rolled_back"
+)
+
+// Event is the structured payload delivered to every sink.
+type Event struct {
+	EventType  EventType              `json:"event_type"`
+	Index      string                 `json:"index"`
+	DocumentID string                 `json:"document_id,omitempty"`
+	Ts         time.Time              `json:"ts"`
+	RequestID  string                 `json:"request_id,omitempty"` // correlates the event back to the HTTP request that caused it
+	Actor      string                 `json:"actor,omitempty"`      // authenticated subject that caused the event, if any
+	Source     map[string]interface{} `json:"_source,omitempty"`    // optional document diff/snapshot
+}
+
+// Notifier publishes events to whatever sinks have been configured. Publish
+// must not block the caller on sink I/O.
+type Notifier interface {
+	Publish(ctx context.Context, event Event)
+}
+
+// Sink delivers events to a single downstream system (AMQP, Redis Streams,
+// NATS, a webhook, ...).
+type Sink interface {
+	// Name identifies the sink, for logging.
+	Name() string
+	// Filter reports which events this sink wants to receive.
+	Filter() SinkFilter
+	// Send delivers a single event. Send is called from a worker goroutine
+	// and may block; it is not on the publisher's hot path.
+	Send(ctx context.Context, event Event) error
+}
+
+// SinkFilter narrows the events delivered to a sink. An empty EventTypes
+// matches every event type, and an empty IndexPattern matches every index.
+type SinkFilter struct {
+	EventTypes   []string `mapstructure:"event_types"`
+	IndexPattern string   `mapstructure:"index_pattern"`
+}
+
+// matches reports whether event passes f.
+func (f SinkFilter) matches(event Event) bool {
+	if len(f.EventTypes) > 0 {
+		ok := false
+		for _, t := range f.EventTypes {
+			if EventType(t) == event.EventType {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+	if f.IndexPattern == "" || f.IndexPattern == "*" {
+		return true
+	}
+	return globMatch(f.IndexPattern, event.Index)
+}
+
+// globMatch supports a single leading/trailing '*' wildcard, which covers
+// the common alias-prefix/suffix patterns without pulling in a dependency.
+func globMatch(pattern, s string) bool {
+	switch {
+	case pattern == s:
+		return true
+	case len(pattern) > 0 && pattern[len(pattern)-1] == '*':
+		prefix := pattern[:len(pattern)-1]
+		return len(s) >= len(prefix) && s[:len(prefix)] == prefix
+	case len(pattern) > 0 && pattern[0] == '*':
+		suffix := pattern[1:]
+		return len(s) >= len(suffix) && s[len(s)-len(suffix):] == suffix
+	default:
+		return false
+	}
+}
+
+// noopNotifier is used when no sinks are configured.
+type noopNotifier struct{}
+
+func (noopNotifier) Publish(context.Context, Event) {}
+
+// defaultNotifier is the package-level Notifier used by Publish. It starts
+// out as a no-op until Initialize is called with a non-empty Config, mirroring
+// logger.InitializeLogger's global-init pattern.
+var defaultNotifier Notifier = noopNotifier{}
+
+// Initialize builds the configured sinks and installs the resulting
+// Dispatcher as the package-level Notifier. Call once at startup. The log
+// sink is on by default (set cfg.Log.Disabled = true to silence it); a nil
+// cfg still gets it, so every mutation is at least logged out of the box.
+func Initialize(cfg *Config) {
+	if cfg == nil {
+		cfg = &Config{}
+	}
+
+	var sinks []Sink
+	if cfg.Log == nil || !cfg.Log.Disabled {
+		sinks = append(sinks, newLogSink(cfg.Log))
+	}
+	if cfg.Webhook != nil && cfg.Webhook.Enabled {
+		sinks = append(sinks, newWebhookSink(cfg.Webhook))
+	}
+	if cfg.AMQP != nil && cfg.AMQP.Enabled {
+		sinks = append(sinks, newAMQPSink(cfg.AMQP))
+	}
+	if cfg.Redis != nil && cfg.Redis.Enabled {
+		sinks = append(sinks, newRedisStreamSink(cfg.Redis))
+	}
+	if cfg.NATS != nil && cfg.NATS.Enabled {
+		sinks = append(sinks, newNATSSink(cfg.NATS))
+	}
+	if len(sinks) == 0 {
+		return
+	}
+
+	bufferSize := cfg.BufferSize
+	if bufferSize <= 0 {
+		bufferSize = 256
+	}
+	workers := cfg.Workers
+	if workers <= 0 {
+		workers = 4
+	}
+
+	defaultNotifier = NewDispatcher(sinks, bufferSize, workers)
+}
+
+// Publish sends event through the package-level Notifier installed by
+// Initialize. It is safe to call even when notifications are disabled.
+func Publish(ctx context.Context, event Event) {
+	defaultNotifier.Publish(ctx, event)
+}
+
+// Default returns the package-level Notifier installed by Initialize, so
+// callers that want to inject it explicitly (e.g. into a handler
+// constructor) instead of going through the global Publish function can
+// get a handle to the same instance.
+func Default() Notifier {
+	return defaultNotifier
+}
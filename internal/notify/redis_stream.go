@@ -0,0 +1,37 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"github.com/goccy/go-json"
+	"github.com/redis/go-redis/v9"
+)
+
+// redisStreamSink delivers events to a Redis Stream via XADD.
+type redisStreamSink struct {
+	cfg    *RedisSinkConfig
+	client *redis.Client
+}
+
+func newRedisStreamSink(cfg *RedisSinkConfig) *redisStreamSink {
+	return &redisStreamSink{
+		cfg:    cfg,
+		client: redis.NewClient(&redis.Options{Addr: cfg.Addr}),
+	}
+}
+
+func (s *redisStreamSink) Name() string { return "redis_stream" }
+
+func (s *redisStreamSink) Filter() SinkFilter { return s.cfg.Filter }
+
+func (s *redisStreamSink) Send(ctx context.Context, event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("redis_stream: failed to marshal event: %w", err)
+	}
+
+	return s.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: s.cfg.Stream,
+		Values: map[string]interface{}{"event": string(payload)},
+	}).Err()
+}
@@ -0,0 +1,104 @@
+package builder
+
+// Agg is an aggregation clause with an Elasticsearch type name ("terms",
+// "date_histogram", ...), so it can be nested as a sub-aggregation.
+type Agg interface {
+	Sourcer
+	// SubAggregate adds a named sub-aggregation, returning the same Agg for
+	// chaining.
+	SubAggregate(name string, agg Agg) Agg
+}
+
+// TermsAgg builds a "terms" bucket aggregation over a field.
+type TermsAgg struct {
+	field string
+	size  int
+	subs  map[string]Agg
+}
+
+// NewTermsAgg creates a TermsAgg bucketing on field.
+func NewTermsAgg(field string) *TermsAgg {
+	return &TermsAgg{field: field, subs: map[string]Agg{}}
+}
+
+// Size sets the number of buckets to return.
+func (a *TermsAgg) Size(size int) *TermsAgg {
+	a.size = size
+	return a
+}
+
+func (a *TermsAgg) SubAggregate(name string, agg Agg) Agg {
+	a.subs[name] = agg
+	return a
+}
+
+func (a *TermsAgg) Source() (map[string]interface{}, error) {
+	terms := map[string]interface{}{"field": a.field}
+	if a.size > 0 {
+		terms["size"] = a.size
+	}
+	src := map[string]interface{}{"terms": terms}
+	if err := attachSubAggs(src, a.subs); err != nil {
+		return nil, err
+	}
+	return src, nil
+}
+
+// DateHistogramAgg builds a "date_histogram" bucket aggregation over a
+// date field.
+type DateHistogramAgg struct {
+	field            string
+	calendarInterval string
+	format           string
+	subs             map[string]Agg
+}
+
+// NewDateHistogramAgg creates a DateHistogramAgg bucketing field by
+// calendarInterval (e.g. "day", "week", "month").
+func NewDateHistogramAgg(field, calendarInterval string) *DateHistogramAgg {
+	return &DateHistogramAgg{field: field, calendarInterval: calendarInterval, subs: map[string]Agg{}}
+}
+
+// Format sets the "format" used to render bucket keys, e.g. "yyyy-MM-dd".
+func (a *DateHistogramAgg) Format(format string) *DateHistogramAgg {
+	a.format = format
+	return a
+}
+
+func (a *DateHistogramAgg) SubAggregate(name string, agg Agg) Agg {
+	a.subs[name] = agg
+	return a
+}
+
+func (a *DateHistogramAgg) Source() (map[string]interface{}, error) {
+	histogram := map[string]interface{}{
+		"field":             a.field,
+		"calendar_interval": a.calendarInterval,
+	}
+	if a.format != "" {
+		histogram["format"] = a.format
+	}
+	src := map[string]interface{}{"date_histogram": histogram}
+	if err := attachSubAggs(src, a.subs); err != nil {
+		return nil, err
+	}
+	return src, nil
+}
+
+// attachSubAggs renders subs and, if any exist, attaches them to dst under
+// the "aggs" key.
+func attachSubAggs(dst map[string]interface{}, subs map[string]Agg) error {
+	if len(subs) == 0 {
+		return nil
+	}
+	rendered := map[string]interface{}{}
+	for name, agg := range subs {
+		src, err := agg.Source()
+		if err != nil {
+			return err
+		}
+		rendered[name] = src
+	}
+	dst["aggs"] = rendered
+	return nil
+}
@@ -0,0 +1,87 @@
+package builder
+
+// BoolQuery builds a "bool" compound query out of must/filter/should/
+// must_not clauses. The zero value is ready to use via NewBoolQuery.
+type BoolQuery struct {
+	must               []Sourcer
+	filter             []Sourcer
+	should             []Sourcer
+	mustNot            []Sourcer
+	minimumShouldMatch interface{}
+}
+
+// NewBoolQuery creates an empty BoolQuery.
+func NewBoolQuery() *BoolQuery {
+	return &BoolQuery{}
+}
+
+// Must adds one or more clauses that must match and contribute to score.
+func (q *BoolQuery) Must(clauses ...Sourcer) *BoolQuery {
+	q.must = append(q.must, clauses...)
+	return q
+}
+
+// Filter adds one or more clauses that must match but don't affect score.
+func (q *BoolQuery) Filter(clauses ...Sourcer) *BoolQuery {
+	q.filter = append(q.filter, clauses...)
+	return q
+}
+
+// Should adds one or more clauses that should match.
+func (q *BoolQuery) Should(clauses ...Sourcer) *BoolQuery {
+	q.should = append(q.should, clauses...)
+	return q
+}
+
+// MustNot adds one or more clauses that must not match.
+func (q *BoolQuery) MustNot(clauses ...Sourcer) *BoolQuery {
+	q.mustNot = append(q.mustNot, clauses...)
+	return q
+}
+
+// MinimumShouldMatch sets the "minimum_should_match" option, e.g. 1 or "75%".
+func (q *BoolQuery) MinimumShouldMatch(value interface{}) *BoolQuery {
+	q.minimumShouldMatch = value
+	return q
+}
+
+// Source renders the bool query to its Elasticsearch DSL map.
+func (q *BoolQuery) Source() (map[string]interface{}, error) {
+	inner := map[string]interface{}{}
+
+	if err := sourceClauses(inner, "must", q.must); err != nil {
+		return nil, err
+	}
+	if err := sourceClauses(inner, "filter", q.filter); err != nil {
+		return nil, err
+	}
+	if err := sourceClauses(inner, "should", q.should); err != nil {
+		return nil, err
+	}
+	if err := sourceClauses(inner, "must_not", q.mustNot); err != nil {
+		return nil, err
+	}
+	if q.minimumShouldMatch != nil {
+		inner["minimum_should_match"] = q.minimumShouldMatch
+	}
+
+	return map[string]interface{}{"bool": inner}, nil
+}
+
+// sourceClauses renders clauses and, if any exist, attaches them to dst
+// under key.
+func sourceClauses(dst map[string]interface{}, key string, clauses []Sourcer) error {
+	if len(clauses) == 0 {
+		return nil
+	}
+	rendered := make([]map[string]interface{}, 0, len(clauses))
+	for _, c := range clauses {
+		src, err := c.Source()
+		if err != nil {
+			return err
+		}
+		rendered = append(rendered, src)
+	}
+	dst[key] = rendered
+	return nil
+}
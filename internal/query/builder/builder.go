@@ -0,0 +1,12 @@
+// Package builder provides typed, fluent constructors for Elasticsearch
+// query DSL fragments, so callers building a search body don't hand-marshal
+// raw maps and risk a silent JSON typo.
+package builder
+
+// Sourcer is anything that can render itself to the Elasticsearch DSL shape
+// it represents (a query clause, an aggregation, a full search body, ...).
+// Source() is called lazily, at marshal time, rather than eagerly building
+// a map on every fluent call.
+type Sourcer interface {
+	Source() (map[string]interface{}, error)
+}
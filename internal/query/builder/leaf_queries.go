@@ -0,0 +1,105 @@
+package builder
+
+// MatchQuery builds a "match" full-text query against a single field.
+type MatchQuery struct {
+	field     string
+	value     interface{}
+	fuzziness string
+}
+
+// NewMatchQuery creates a MatchQuery for field matching value.
+func NewMatchQuery(field string, value interface{}) *MatchQuery {
+	return &MatchQuery{field: field, value: value}
+}
+
+// Fuzziness sets the "fuzziness" option, e.g. "AUTO", "1", "2".
+func (q *MatchQuery) Fuzziness(fuzziness string) *MatchQuery {
+	q.fuzziness = fuzziness
+	return q
+}
+
+func (q *MatchQuery) Source() (map[string]interface{}, error) {
+	if q.fuzziness == "" {
+		return map[string]interface{}{
+			"match": map[string]interface{}{q.field: q.value},
+		}, nil
+	}
+	return map[string]interface{}{
+		"match": map[string]interface{}{
+			q.field: map[string]interface{}{
+				"query":     q.value,
+				"fuzziness": q.fuzziness,
+			},
+		},
+	}, nil
+}
+
+// TermQuery builds a "term" exact-value filter against a single field.
+type TermQuery struct {
+	field string
+	value interface{}
+}
+
+// NewTermQuery creates a TermQuery for field matching value exactly.
+func NewTermQuery(field string, value interface{}) *TermQuery {
+	return &TermQuery{field: field, value: value}
+}
+
+func (q *TermQuery) Source() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"term": map[string]interface{}{q.field: q.value},
+	}, nil
+}
+
+// MatchAllQuery builds a "match_all" query, matching every document.
+type MatchAllQuery struct{}
+
+// NewMatchAllQuery creates a MatchAllQuery.
+func NewMatchAllQuery() *MatchAllQuery {
+	return &MatchAllQuery{}
+}
+
+func (*MatchAllQuery) Source() (map[string]interface{}, error) {
+	return map[string]interface{}{"match_all": map[string]interface{}{}}, nil
+}
+
+// RangeQuery builds a "range" query against a single field.
+type RangeQuery struct {
+	field  string
+	bounds map[string]interface{}
+}
+
+// NewRangeQuery creates a RangeQuery for field.
+func NewRangeQuery(field string) *RangeQuery {
+	return &RangeQuery{field: field, bounds: map[string]interface{}{}}
+}
+
+// Gte sets the "gte" (greater-than-or-equal) bound.
+func (q *RangeQuery) Gte(value interface{}) *RangeQuery {
+	q.bounds["gte"] = value
+	return q
+}
+
+// Lte sets the "lte" (less-than-or-equal) bound.
+func (q *RangeQuery) Lte(value interface{}) *RangeQuery {
+	q.bounds["lte"] = value
+	return q
+}
+
+// Gt sets the "gt" (greater-than) bound.
+func (q *RangeQuery) Gt(value interface{}) *RangeQuery {
+	q.bounds["gt"] = value
+	return q
+}
+
+// Lt sets the "lt" (less-than) bound.
+func (q *RangeQuery) Lt(value interface{}) *RangeQuery {
+	q.bounds["lt"] = value
+	return q
+}
+
+func (q *RangeQuery) Source() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"range": map[string]interface{}{q.field: q.bounds},
+	}, nil
+}
@@ -0,0 +1,183 @@
+package builder
+
+// SortOrder is the direction of a sort clause.
+type SortOrder string
+
+const (
+	SortAsc  SortOrder = "asc"
+	SortDesc SortOrder = "desc"
+)
+
+// SortClause orders results by a single field.
+type SortClause struct {
+	field string
+	order SortOrder
+}
+
+// NewSort creates a SortClause on field in order.
+func NewSort(field string, order SortOrder) SortClause {
+	return SortClause{field: field, order: order}
+}
+
+func (s SortClause) source() map[string]interface{} {
+	return map[string]interface{}{s.field: map[string]interface{}{"order": s.order}}
+}
+
+// Highlight builds the "highlight" section of a search request.
+type Highlight struct {
+	fields map[string]interface{}
+}
+
+// NewHighlight creates an empty Highlight.
+func NewHighlight() *Highlight {
+	return &Highlight{fields: map[string]interface{}{}}
+}
+
+// Field adds field to the set of highlighted fields, using Elasticsearch's
+// default highlighter settings for it.
+func (h *Highlight) Field(field string) *Highlight {
+	h.fields[field] = map[string]interface{}{}
+	return h
+}
+
+func (h *Highlight) source() map[string]interface{} {
+	return map[string]interface{}{"fields": h.fields}
+}
+
+// SourceFilter controls which document fields "_source" returns.
+type SourceFilter struct {
+	includes []string
+	excludes []string
+}
+
+// NewSourceFilter creates a SourceFilter returning only includes, minus
+// excludes.
+func NewSourceFilter(includes, excludes []string) *SourceFilter {
+	return &SourceFilter{includes: includes, excludes: excludes}
+}
+
+func (f *SourceFilter) source() interface{} {
+	if len(f.excludes) == 0 {
+		return f.includes
+	}
+	return map[string]interface{}{"includes": f.includes, "excludes": f.excludes}
+}
+
+// SearchRequest is the top-level fluent builder for a search body: query,
+// aggregations, sort, highlighting, source filtering, and pagination.
+type SearchRequest struct {
+	query     Sourcer
+	aggs      map[string]Agg
+	sorts     []SortClause
+	highlight *Highlight
+	source    *SourceFilter
+	from      int
+	size      int
+	minScore  float64
+}
+
+// NewSearchRequest creates an empty SearchRequest.
+func NewSearchRequest() *SearchRequest {
+	return &SearchRequest{aggs: map[string]Agg{}}
+}
+
+// Query sets the top-level query clause.
+func (r *SearchRequest) Query(q Sourcer) *SearchRequest {
+	r.query = q
+	return r
+}
+
+// Aggregate adds a named top-level aggregation.
+func (r *SearchRequest) Aggregate(name string, agg Agg) *SearchRequest {
+	r.aggs[name] = agg
+	return r
+}
+
+// Sort adds a sort clause; clauses are applied in the order added.
+func (r *SearchRequest) Sort(s SortClause) *SearchRequest {
+	r.sorts = append(r.sorts, s)
+	return r
+}
+
+// Highlight sets the highlight configuration.
+func (r *SearchRequest) Highlight(h *Highlight) *SearchRequest {
+	r.highlight = h
+	return r
+}
+
+// SourceFilter sets which fields "_source" returns.
+func (r *SearchRequest) SourceFilter(f *SourceFilter) *SearchRequest {
+	r.source = f
+	return r
+}
+
+// From sets the pagination offset.
+func (r *SearchRequest) From(from int) *SearchRequest {
+	r.from = from
+	return r
+}
+
+// Size sets the pagination page size.
+func (r *SearchRequest) Size(size int) *SearchRequest {
+	r.size = size
+	return r
+}
+
+// MinScore sets the minimum "_score" a hit must have to be returned.
+func (r *SearchRequest) MinScore(minScore float64) *SearchRequest {
+	r.minScore = minScore
+	return r
+}
+
+// Source renders the full search request body.
+func (r *SearchRequest) Source() (map[string]interface{}, error) {
+	body := map[string]interface{}{}
+
+	if r.query != nil {
+		src, err := r.query.Source()
+		if err != nil {
+			return nil, err
+		}
+		body["query"] = src
+	}
+
+	if len(r.aggs) > 0 {
+		aggs := map[string]interface{}{}
+		for name, agg := range r.aggs {
+			src, err := agg.Source()
+			if err != nil {
+				return nil, err
+			}
+			aggs[name] = src
+		}
+		body["aggs"] = aggs
+	}
+
+	if len(r.sorts) > 0 {
+		sorts := make([]map[string]interface{}, 0, len(r.sorts))
+		for _, s := range r.sorts {
+			sorts = append(sorts, s.source())
+		}
+		body["sort"] = sorts
+	}
+
+	if r.highlight != nil {
+		body["highlight"] = r.highlight.source()
+	}
+
+	if r.source != nil {
+		body["_source"] = r.source.source()
+	}
+
+	if r.from > 0 {
+		body["from"] = r.from
+	}
+	if r.size > 0 {
+		body["size"] = r.size
+	}
+	if r.minScore > 0 {
+		body["min_score"] = r.minScore
+	}
+
+	return body, nil
+}
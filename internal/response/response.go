@@ -31,6 +31,13 @@ func Init(details bool) {
 	d = details
 }
 
+// DetailsEnabled reports the details flag set via Init, so callers building
+// their own response payloads (e.g. partial-failure details on an otherwise
+// successful bulk operation) can honor the same setting.
+func DetailsEnabled() bool {
+	return d
+}
+
 // SendErrorResponse sends a JSON response with the given error response.
 //
 // If the details flag was set to false when the package was initialized,
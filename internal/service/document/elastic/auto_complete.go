@@ -3,17 +3,49 @@ package elastic
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"github.com/goccy/go-json"
 	"go-es/internal/errors"
+	"go-es/internal/esc"
+	"go-es/internal/service/document/model/request"
 	"go-es/internal/service/document/model/response"
 	"net/http"
 )
 
 // AutoComplete performs an autocomplete suggestion query on an Elasticsearch index.
 //
-// It sends a search request to the Elasticsearch server using the provided index name and query payload,
-// and returns the parsed suggestions or an error if the operation fails.
-func (e *elastic) AutoComplete(ctx context.Context, indexName string, payload []byte) (*response.SuggestResponse, error) {
+// The query body and response parsing are delegated to the SuggestStrategy
+// matching mode, since the completion/phrase suggesters and the edge_ngram/
+// search_as_you_type field queries have incompatible request and response
+// shapes.
+func (e *elastic) AutoComplete(ctx context.Context, indexName string, field string, input string, mode request.SuggestMode, opts SuggestOptions) (*response.SuggestResponse, error) {
+	// e.version is normally already gated by esc.NewClientWithVersionCheck at
+	// startup; this re-checks it so a client constructed another way still
+	// rejects an unsupported cluster here rather than sending a suggester
+	// payload shape (e.g. "contexts") the connected version may not support.
+	if e.version != nil {
+		if ok, err := e.version.AtLeast(esc.DefaultMinVersion); err == nil && !ok {
+			return nil, errors.ElasticsearchError{
+				StatusCode: http.StatusPreconditionFailed,
+				Message:    "cluster version is below the minimum supported version",
+				Details:    fmt.Errorf("cluster version %s is below the minimum supported version %s", e.version.Number, esc.DefaultMinVersion),
+				Type:       errors.UnsupportedVersionError,
+			}
+		}
+	}
+
+	strategy := strategyFor(mode)
+
+	payload, err := json.Marshal(strategy.BuildQuery(field, input, opts))
+	if err != nil {
+		return nil, errors.ElasticsearchError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "failed to marshal suggestion query",
+			Details:    err,
+			Type:       errors.MarhshalingError,
+		}
+	}
+
 	// Execute the search request
 	res, err := e.client.Search(
 		e.client.Search.WithContext(ctx),
@@ -21,12 +53,7 @@ func (e *elastic) AutoComplete(ctx context.Context, indexName string, payload []
 		e.client.Search.WithBody(bytes.NewReader(payload)),
 	)
 	if err != nil {
-		return nil, errors.ElasticsearchError{
-			StatusCode: http.StatusInternalServerError,
-			Message:    "failed to connect to elastic server",
-			Details:    err,
-			Type:       errors.ConnectionError,
-		}
+		return nil, errors.NewConnectionError(err)
 	}
 	defer res.Body.Close()
 
@@ -46,24 +73,8 @@ func (e *elastic) AutoComplete(ctx context.Context, indexName string, payload []
 		}
 	}
 
-	// Extract suggestions from the parsed response
-	suggestions := []string{}
-	if suggest, found := parsedRes["suggest"]; found {
-		if nameSuggestion, ok := suggest.(map[string]interface{})["name_suggestion"].([]interface{}); ok {
-			for _, s := range nameSuggestion {
-				if options, ok := s.(map[string]interface{})["options"].([]interface{}); ok {
-					for _, option := range options {
-						if text, ok := option.(map[string]interface{})["text"].(string); ok {
-							suggestions = append(suggestions, text)
-						}
-					}
-				}
-			}
-		}
-	}
-
 	// Return the suggestion response
 	return &response.SuggestResponse{
-		Suggestions: suggestions,
+		Suggestions: strategy.ParseSuggestions(parsedRes, field),
 	}, nil
 }
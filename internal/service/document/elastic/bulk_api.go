@@ -0,0 +1,269 @@
+package elastic
+
+import (
+	"github.com/elastic/go-elasticsearch/v8"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// BulkRequest is a single queued action submitted to a BulkAPI: a
+// BulkIndexRequest, BulkUpdateRequest, or BulkDeleteRequest.
+type BulkRequest interface {
+	toBulkable() BulkableRequest
+}
+
+// BulkIndexRequest indexes Doc into Index, assigning an ID if empty.
+type BulkIndexRequest struct {
+	Index string
+	ID    string
+	Doc   map[string]interface{}
+}
+
+func (r BulkIndexRequest) toBulkable() BulkableRequest {
+	return BulkableRequest{Action: "index", Index: r.Index, ID: r.ID, Doc: r.Doc}
+}
+
+// BulkUpdateRequest partially updates the document ID in Index with Doc.
+type BulkUpdateRequest struct {
+	Index string
+	ID    string
+	Doc   map[string]interface{}
+}
+
+func (r BulkUpdateRequest) toBulkable() BulkableRequest {
+	return BulkableRequest{Action: "update", Index: r.Index, ID: r.ID, Doc: r.Doc}
+}
+
+// BulkDeleteRequest deletes document ID from Index.
+type BulkDeleteRequest struct {
+	Index string
+	ID    string
+}
+
+func (r BulkDeleteRequest) toBulkable() BulkableRequest {
+	return BulkableRequest{Action: "delete", Index: r.Index, ID: r.ID}
+}
+
+// BeforeFn is invoked with a chunk's requests immediately before it is sent
+// to Elasticsearch, so callers can log or audit what's about to be flushed.
+type BeforeFn func(reqs []BulkRequest)
+
+// BulkAPIAfterFn is invoked once per flushed chunk after the bulk request
+// (and any retries) complete, reporting how many of the chunk's items
+// ultimately succeeded versus failed.
+type BulkAPIAfterFn func(reqs []BulkRequest, succeeded, failed int, err error)
+
+// BulkAPIOptions configures a BulkAPI's flush thresholds and retry policy.
+type BulkAPIOptions struct {
+	QueueSize     int           // size of the inbound channel buffer
+	BulkActions   int           // flush once this many items are queued
+	BulkBytes     int           // flush once the queued payload exceeds this many bytes
+	FlushInterval time.Duration // periodically flush even if thresholds aren't met
+	Backoff       BackoffPolicy // retry policy applied per-item on 429/503
+}
+
+// DefaultBulkAPIOptions returns sane defaults for BulkAPIOptions.
+func DefaultBulkAPIOptions() BulkAPIOptions {
+	return BulkAPIOptions{
+		QueueSize:     1000,
+		BulkActions:   500,
+		BulkBytes:     4 << 20, // 4MB
+		FlushInterval: 5 * time.Second,
+		Backoff:       DefaultBackoffPolicy(),
+	}
+}
+
+// BulkAPIStats reports cumulative counters for a BulkAPI.
+type BulkAPIStats struct {
+	Indexed int64
+	Failed  int64
+	Retried int64
+	Bytes   int64
+}
+
+// BulkAPI is a background bulk-ingest pipeline: callers Submit requests onto
+// an inbound channel without blocking on Elasticsearch, and a single worker
+// goroutine batches them, flushing on size/count thresholds or a timer, and
+// retries failed items with the same exponential-jitter backoff as
+// BulkProcessor.
+//
+// Unlike BulkProcessor, which is built fresh per call (e.g. by
+// ImportDocuments), a BulkAPI is meant to be long-lived: one instance
+// serving a stream of Submit calls from HTTP handlers for the life of the
+// process.
+type BulkAPI struct {
+	client *elasticsearch.Client
+	opts   BulkAPIOptions
+	before BeforeFn
+	after  BulkAPIAfterFn
+
+	inbox chan BulkRequest
+	done  chan struct{}
+	wg    sync.WaitGroup
+
+	stats BulkAPIStats
+}
+
+// NewBulkAPI creates a BulkAPI bound to client and starts its background
+// worker. Callers must call Close to flush any remaining items and stop the
+// worker.
+func NewBulkAPI(client *elasticsearch.Client, opts BulkAPIOptions, before BeforeFn, after BulkAPIAfterFn) *BulkAPI {
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = 1000
+	}
+	a := &BulkAPI{
+		client: client,
+		opts:   opts,
+		before: before,
+		after:  after,
+		inbox:  make(chan BulkRequest, opts.QueueSize),
+		done:   make(chan struct{}),
+	}
+	a.wg.Add(1)
+	go a.run()
+	return a
+}
+
+// Submit queues req for the next flush. It blocks only while the inbound
+// queue (QueueSize) is full; the actual Elasticsearch call happens
+// asynchronously on the background worker.
+func (a *BulkAPI) Submit(req BulkRequest) {
+	a.inbox <- req
+}
+
+// Stats returns a snapshot of the processor's cumulative counters.
+func (a *BulkAPI) Stats() BulkAPIStats {
+	return BulkAPIStats{
+		Indexed: atomic.LoadInt64(&a.stats.Indexed),
+		Failed:  atomic.LoadInt64(&a.stats.Failed),
+		Retried: atomic.LoadInt64(&a.stats.Retried),
+		Bytes:   atomic.LoadInt64(&a.stats.Bytes),
+	}
+}
+
+// Close stops the background worker after flushing any queued items.
+func (a *BulkAPI) Close() {
+	close(a.done)
+	a.wg.Wait()
+}
+
+// run batches requests off the inbox until BulkActions/BulkBytes is
+// exceeded or FlushInterval elapses, flushing each batch through a
+// throwaway BulkProcessor so the retry/backoff logic isn't duplicated.
+func (a *BulkAPI) run() {
+	defer a.wg.Done()
+
+	var tickerC <-chan time.Time
+	if a.opts.FlushInterval > 0 {
+		ticker := time.NewTicker(a.opts.FlushInterval)
+		defer ticker.Stop()
+		tickerC = ticker.C
+	}
+
+	var batch []BulkRequest
+	var bufSize int
+
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		a.flush(batch)
+		batch = nil
+		bufSize = 0
+	}
+
+	for {
+		select {
+		case req := <-a.inbox:
+			batch = append(batch, req)
+			bufSize += estimateSize(req)
+			if len(batch) >= a.opts.BulkActions || (a.opts.BulkBytes > 0 && bufSize >= a.opts.BulkBytes) {
+				flush()
+			}
+		case <-tickerC:
+			flush()
+		case <-a.done:
+			for {
+				select {
+				case req := <-a.inbox:
+					batch = append(batch, req)
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+// flush sends batch through a one-shot BulkProcessor, reusing its retry and
+// backoff behavior, then folds the resulting stats into a.stats and invokes
+// before/after.
+func (a *BulkAPI) flush(batch []BulkRequest) {
+	if a.before != nil {
+		a.before(batch)
+	}
+
+	bulkable := make([]BulkableRequest, len(batch))
+	for i, req := range batch {
+		bulkable[i] = req.toBulkable()
+	}
+
+	var flushErr error
+	processor := NewBulkProcessor(a.client, BulkProcessorOptions{
+		BulkActions: len(bulkable) + 1, // never reached by Add, so Close below sends the batch in one flush
+		Backoff:     a.opts.Backoff,
+	}, nil)
+	for _, req := range bulkable {
+		if err := processor.Add(req); err != nil {
+			flushErr = err
+		}
+	}
+	if err := processor.Close(); err != nil {
+		flushErr = err
+	}
+
+	stats := processor.Stats()
+	atomic.AddInt64(&a.stats.Indexed, stats.Indexed)
+	atomic.AddInt64(&a.stats.Failed, stats.Failed)
+	atomic.AddInt64(&a.stats.Retried, stats.Retried)
+	atomic.AddInt64(&a.stats.Bytes, stats.Bytes)
+
+	if a.after != nil {
+		a.after(batch, int(stats.Indexed), int(stats.Failed), flushErr)
+	}
+}
+
+// estimateSize returns a rough byte estimate for req, used only to decide
+// when to flush; it need not be exact.
+func estimateSize(req BulkRequest) int {
+	bulkable := req.toBulkable()
+	size := len(bulkable.Index) + len(bulkable.ID)
+	for k, v := range bulkable.Doc {
+		size += len(k)
+		if s, ok := v.(string); ok {
+			size += len(s)
+		} else {
+			size += 8
+		}
+	}
+	return size
+}
+
+// activeBulkAPI holds the process-lifetime BulkAPI created by BulkAPIFor, so
+// repeated calls share one background worker instead of spawning a new
+// goroutine per request.
+var (
+	activeBulkAPIOnce sync.Once
+	activeBulkAPI     *BulkAPI
+)
+
+// BulkAPIFor returns the shared BulkAPI bound to client, creating it with
+// DefaultBulkAPIOptions on first use.
+func BulkAPIFor(client *elasticsearch.Client) *BulkAPI {
+	activeBulkAPIOnce.Do(func() {
+		activeBulkAPI = NewBulkAPI(client, DefaultBulkAPIOptions(), nil, nil)
+	})
+	return activeBulkAPI
+}
@@ -0,0 +1,85 @@
+package elastic
+
+import (
+	"context"
+)
+
+// BulkOptions controls the chunking, concurrency, and retry behavior of
+// BulkIndex.
+type BulkOptions struct {
+	NumWorkers    int // number of concurrent flush workers, 0 uses esutil's default (runtime.NumCPU())
+	FlushBytes    int // flush once the buffered request body reaches this size, 0 uses esutil's default (5MB)
+	FlushInterval int // flush interval in milliseconds, 0 uses esutil's default (30s)
+	MaxRetries    int // retries attempted for a single document on a transient failure, with exponential backoff
+}
+
+// DefaultBulkOptions returns sane defaults for BulkOptions.
+func DefaultBulkOptions() BulkOptions {
+	return BulkOptions{
+		NumWorkers: 4,
+		FlushBytes: 5 << 20,
+		MaxRetries: 3,
+	}
+}
+
+// BulkFailedItem reports a document that could not be indexed after
+// exhausting retries. ID identifies it when the original doc carried one;
+// a doc indexed without an explicit "id" gets one assigned by
+// Elasticsearch, so there's nothing stable to report for it here.
+type BulkFailedItem struct {
+	ID     string `json:"id,omitempty"`
+	Status int    `json:"status"`
+	Reason string `json:"reason"`
+}
+
+// BulkResult reports the outcome of a BulkIndex call.
+type BulkResult struct {
+	Indexed     int64            `json:"indexed"`
+	Failed      int64            `json:"failed"`
+	FailedItems []BulkFailedItem `json:"failed_items,omitempty"`
+}
+
+// BulkIndex indexes docs into index. It is a convenience wrapper around
+// BulkIngest for the common case of a flat array of documents that should
+// all be "index" operations: it builds one BulkIngestItem per doc (picking
+// up an "id" field if present) and adapts the resulting BulkIngestSummary
+// into the index-oriented BulkResult/BulkFailedItem shape this endpoint has
+// always returned, rather than running its own esutil.BulkIndexer/retry
+// engine alongside BulkIngest's.
+func (e *elastic) BulkIndex(ctx context.Context, index string, docs []map[string]interface{}, opts BulkOptions) (*BulkResult, error) {
+	items := make([]BulkIngestItem, len(docs))
+	for i, doc := range docs {
+		item := BulkIngestItem{Action: "index", Doc: doc}
+		if id, ok := doc["id"].(string); ok {
+			item.ID = id
+		}
+		items[i] = item
+	}
+
+	summary, err := e.BulkIngest(ctx, index, items, BulkIngestOptions{
+		NumWorkers:    opts.NumWorkers,
+		FlushBytes:    opts.FlushBytes,
+		FlushInterval: opts.FlushInterval,
+		MaxRetries:    opts.MaxRetries,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BulkResult{
+		Indexed: summary.Indexed,
+		Failed:  summary.Failed,
+	}
+	if len(summary.FailedItems) > 0 {
+		result.FailedItems = make([]BulkFailedItem, len(summary.FailedItems))
+		for i, f := range summary.FailedItems {
+			result.FailedItems[i] = BulkFailedItem{
+				ID:     f.DocID,
+				Status: f.Status,
+				Reason: f.Reason,
+			}
+		}
+	}
+
+	return result, nil
+}
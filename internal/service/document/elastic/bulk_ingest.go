@@ -0,0 +1,280 @@
+package elastic
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/cenkalti/backoff/v4"
+	"github.com/elastic/go-elasticsearch/v8/esutil"
+	"github.com/goccy/go-json"
+	"go-es/internal/errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// BulkIngestOptions controls the concurrency, flush, and retry behavior of
+// BulkIngest.
+type BulkIngestOptions struct {
+	NumWorkers    int // number of concurrent flush workers, 0 uses esutil's default (runtime.NumCPU())
+	FlushBytes    int // flush once the buffered request body reaches this size, 0 uses esutil's default (5MB)
+	FlushInterval int // flush interval in milliseconds, 0 uses esutil's default (30s)
+	MaxRetries    int // retries attempted for a single item on a transient failure, with exponential backoff
+}
+
+// DefaultBulkIngestOptions returns sane defaults for BulkIngestOptions.
+func DefaultBulkIngestOptions() BulkIngestOptions {
+	return BulkIngestOptions{
+		NumWorkers: 4,
+		FlushBytes: 5 << 20,
+		MaxRetries: 3,
+	}
+}
+
+// BulkIngestItem is a single document to ingest via BulkIngest. Action is
+// one of "index", "create", "update", or "delete"; ID is optional for
+// "index"/"create" (Elasticsearch assigns one) but required for
+// "update"/"delete".
+type BulkIngestItem struct {
+	Action string
+	ID     string
+	Doc    map[string]interface{}
+}
+
+// FailedItem reports a document that could not be ingested after exhausting
+// retries.
+type FailedItem struct {
+	DocID     string `json:"doc_id"`
+	Status    int    `json:"status"`
+	ErrorType string `json:"error_type"`
+	Reason    string `json:"reason"`
+}
+
+// BulkIngestSummary reports the outcome of a BulkIngest call.
+type BulkIngestSummary struct {
+	Indexed     int64        `json:"indexed"`
+	Created     int64        `json:"created"`
+	Updated     int64        `json:"updated"`
+	Deleted     int64        `json:"deleted"`
+	Failed      int64        `json:"failed"`
+	FailedItems []FailedItem `json:"failed_items,omitempty"`
+}
+
+// transientStatuses are the HTTP statuses BulkIngest retries; everything
+// else (4xx mapping errors, version conflicts, etc.) is a permanent failure.
+var transientStatuses = map[int]bool{
+	http.StatusTooManyRequests:    true,
+	http.StatusBadGateway:         true,
+	http.StatusServiceUnavailable: true,
+	http.StatusGatewayTimeout:     true,
+}
+
+// toDuration converts a millisecond option (0 meaning "use esutil's
+// default") into a time.Duration for esutil.BulkIndexerConfig.
+func toDuration(ms int) time.Duration {
+	if ms <= 0 {
+		return 0
+	}
+	return time.Duration(ms) * time.Millisecond
+}
+
+// BulkIngest ingests items into index through an esutil.BulkIndexer,
+// retrying transient per-item failures (429, 502/503/504, connection
+// resets) with exponential backoff before giving up on them. It returns a
+// summary of how many documents were indexed/created/updated/deleted, plus
+// the details of any documents that failed permanently.
+func (e *elastic) BulkIngest(ctx context.Context, index string, items []BulkIngestItem, opts BulkIngestOptions) (*BulkIngestSummary, error) {
+	summary := &BulkIngestSummary{}
+	var mu sync.Mutex
+	var indexed, created, updated, deleted int64
+
+	bi, err := esutil.NewBulkIndexer(esutil.BulkIndexerConfig{
+		Index:         index,
+		Client:        e.client,
+		NumWorkers:    opts.NumWorkers,
+		FlushBytes:    opts.FlushBytes,
+		FlushInterval: toDuration(opts.FlushInterval),
+	})
+	if err != nil {
+		return nil, errors.ElasticsearchError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "failed to create bulk indexer",
+			Details:    err,
+			Type:       errors.ServerError,
+		}
+	}
+
+	for _, item := range items {
+		item := item
+
+		biItem, err := e.toBulkIndexerItem(item)
+		if err != nil {
+			return nil, err
+		}
+
+		biItem.OnSuccess = func(_ context.Context, _ esutil.BulkIndexerItem, _ esutil.BulkIndexerResponseItem) {
+			switch item.Action {
+			case "create":
+				atomic.AddInt64(&created, 1)
+			case "update":
+				atomic.AddInt64(&updated, 1)
+			case "delete":
+				atomic.AddInt64(&deleted, 1)
+			default:
+				atomic.AddInt64(&indexed, 1)
+			}
+		}
+		biItem.OnFailure = func(ctx context.Context, _ esutil.BulkIndexerItem, res esutil.BulkIndexerResponseItem, err error) {
+			status, errType, reason := res.Status, "", ""
+			if res.Error.Type != "" {
+				errType, reason = res.Error.Type, res.Error.Reason
+			} else if err != nil {
+				reason = err.Error()
+			}
+
+			if transientStatuses[status] || (status == 0 && err != nil) {
+				status, errType, reason, err = e.retryItem(ctx, index, item, opts.MaxRetries)
+				if err == nil {
+					switch item.Action {
+					case "update":
+						atomic.AddInt64(&updated, 1)
+					case "delete":
+						atomic.AddInt64(&deleted, 1)
+					case "create":
+						atomic.AddInt64(&created, 1)
+					default:
+						atomic.AddInt64(&indexed, 1)
+					}
+					return
+				}
+			}
+
+			mu.Lock()
+			summary.FailedItems = append(summary.FailedItems, FailedItem{
+				DocID:     item.ID,
+				Status:    status,
+				ErrorType: errType,
+				Reason:    reason,
+			})
+			mu.Unlock()
+		}
+
+		if err := bi.Add(ctx, biItem); err != nil {
+			return nil, errors.ElasticsearchError{
+				StatusCode: http.StatusInternalServerError,
+				Message:    "failed to queue bulk item",
+				Details:    err,
+				Type:       errors.ServerError,
+			}
+		}
+	}
+
+	if err := bi.Close(ctx); err != nil {
+		return nil, errors.ElasticsearchError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "failed to flush bulk indexer",
+			Details:    err,
+			Type:       errors.ServerError,
+		}
+	}
+
+	summary.Indexed = atomic.LoadInt64(&indexed)
+	summary.Created = atomic.LoadInt64(&created)
+	summary.Updated = atomic.LoadInt64(&updated)
+	summary.Deleted = atomic.LoadInt64(&deleted)
+	summary.Failed = int64(len(summary.FailedItems))
+
+	return summary, nil
+}
+
+// toBulkIndexerItem builds the esutil item for a single BulkIngestItem,
+// marshaling its Doc unless the action is "delete" (which carries no body).
+func (e *elastic) toBulkIndexerItem(item BulkIngestItem) (esutil.BulkIndexerItem, error) {
+	biItem := esutil.BulkIndexerItem{
+		Action:     item.Action,
+		DocumentID: item.ID,
+	}
+	if item.Action == "delete" {
+		return biItem, nil
+	}
+
+	docJSON, err := json.Marshal(item.Doc)
+	if err != nil {
+		return esutil.BulkIndexerItem{}, errors.ElasticsearchError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "failed to marshal document",
+			Details:    err,
+			Type:       errors.MarhshalingError,
+		}
+	}
+	biItem.Body = bytes.NewReader(docJSON)
+	return biItem, nil
+}
+
+// retryItem resubmits a single failed item directly via the Bulk API,
+// retrying with exponential backoff as long as Elasticsearch keeps
+// returning a transient status. It returns the final status/error details
+// from the last attempt, and a non-nil err if the item never succeeded.
+func (e *elastic) retryItem(ctx context.Context, index string, item BulkIngestItem, maxRetries int) (status int, errType, reason string, err error) {
+	bo := backoff.WithMaxRetries(backoff.NewExponentialBackOff(), uint64(maxRetries))
+
+	opErr := backoff.Retry(func() error {
+		meta, merr := json.Marshal(map[string]interface{}{
+			item.Action: map[string]interface{}{"_index": index, "_id": item.ID},
+		})
+		if merr != nil {
+			return backoff.Permanent(merr)
+		}
+
+		var buf bytes.Buffer
+		buf.Write(meta)
+		buf.WriteByte('\n')
+		if item.Action != "delete" {
+			docJSON, merr := json.Marshal(item.Doc)
+			if merr != nil {
+				return backoff.Permanent(merr)
+			}
+			buf.Write(docJSON)
+			buf.WriteByte('\n')
+		}
+
+		res, reqErr := e.client.Bulk(bytes.NewReader(buf.Bytes()), e.client.Bulk.WithContext(ctx))
+		if reqErr != nil {
+			return reqErr
+		}
+		defer res.Body.Close()
+
+		var parsed struct {
+			Items []map[string]struct {
+				Status int    `json:"status"`
+				Result string `json:"result"`
+				Error  *struct {
+					Type   string `json:"type"`
+					Reason string `json:"reason"`
+				} `json:"error"`
+			} `json:"items"`
+		}
+		if decErr := json.NewDecoder(res.Body).Decode(&parsed); decErr != nil {
+			return decErr
+		}
+		if len(parsed.Items) == 0 {
+			return fmt.Errorf("empty bulk response")
+		}
+
+		for _, result := range parsed.Items[0] {
+			status = result.Status
+			if result.Error == nil {
+				return nil
+			}
+			errType, reason = result.Error.Type, result.Error.Reason
+			if transientStatuses[result.Status] {
+				return fmt.Errorf("transient error: %s", reason)
+			}
+			return backoff.Permanent(fmt.Errorf(reason))
+		}
+		return nil
+	}, bo)
+
+	return status, errType, reason, opErr
+}
@@ -0,0 +1,410 @@
+package elastic
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/goccy/go-json"
+	"go-es/internal/errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// BulkableRequest represents a single action queued on a BulkProcessor.
+//
+// Action is one of "index", "create", "update", or "delete". ID is optional;
+// when empty, Elasticsearch assigns the document ID.
+type BulkableRequest struct {
+	Action string
+	Index  string
+	ID     string
+	Doc    map[string]interface{}
+}
+
+// BackoffPolicy configures the exponential backoff with jitter applied to
+// retryable per-item failures (HTTP 429 and 503).
+type BackoffPolicy struct {
+	InitialInterval time.Duration // base delay before the first retry
+	MaxInterval     time.Duration // cap on any single retry delay
+	MaxRetries      int           // maximum number of retries per item
+}
+
+// DefaultBackoffPolicy returns sane defaults for BackoffPolicy.
+func DefaultBackoffPolicy() BackoffPolicy {
+	return BackoffPolicy{
+		InitialInterval: 200 * time.Millisecond,
+		MaxInterval:     5 * time.Second,
+		MaxRetries:      3,
+	}
+}
+
+// nextDelay returns the exponential backoff delay with full jitter for the
+// given retry attempt (0-indexed).
+func (b BackoffPolicy) nextDelay(attempt int) time.Duration {
+	cap := float64(b.MaxInterval)
+	base := float64(b.InitialInterval)
+	maxDelay := math.Min(cap, base*math.Pow(2, float64(attempt)))
+	return time.Duration(rand.Int63n(int64(maxDelay) + 1))
+}
+
+// BulkProcessorOptions configures a BulkProcessor's flush and retry behavior.
+type BulkProcessorOptions struct {
+	BulkActions   int           // flush once this many items are queued
+	BulkSize      int           // flush once the queued payload exceeds this many bytes
+	FlushInterval time.Duration // periodically flush even if thresholds aren't met
+	Workers       int           // number of concurrent flush workers
+	Backoff       BackoffPolicy // retry policy applied per-item on 429/503
+}
+
+// DefaultBulkProcessorOptions returns sane defaults for BulkProcessorOptions.
+func DefaultBulkProcessorOptions() BulkProcessorOptions {
+	return BulkProcessorOptions{
+		BulkActions:   500,
+		BulkSize:      4 << 20, // 4MB
+		FlushInterval: 5 * time.Second,
+		Workers:       1,
+		Backoff:       DefaultBackoffPolicy(),
+	}
+}
+
+// BulkStats reports cumulative counters for a BulkProcessor.
+type BulkStats struct {
+	Indexed int64
+	Failed  int64
+	Retried int64
+	Bytes   int64
+}
+
+// AfterFunc is invoked once per flushed chunk after the bulk request (and any
+// retries) complete, so callers can log or collect per-item failures.
+type AfterFunc func(requests []BulkableRequest, response map[string]interface{}, err error)
+
+// BulkProcessor batches BulkableRequest items and flushes them to
+// Elasticsearch's _bulk endpoint, retrying only the items that fail with a
+// retryable status (429, 503).
+//
+// It mirrors the olivere/elastic BulkProcessor pattern on top of the
+// go-elasticsearch client: callers Add items and the processor flushes them
+// when BulkActions or BulkSize is exceeded, on a FlushInterval timer, or on
+// an explicit Flush/Close call.
+type BulkProcessor struct {
+	client *elasticsearch.Client
+	opts   BulkProcessorOptions
+	after  AfterFunc
+
+	mu      sync.Mutex
+	queued  []BulkableRequest
+	bufSize int
+
+	flushSem chan struct{}
+	wg       sync.WaitGroup
+
+	timer   *time.Timer
+	closed  bool
+	statsMu sync.Mutex
+	stats   BulkStats
+}
+
+// NewBulkProcessor creates a BulkProcessor bound to client, using opts to
+// control flush thresholds and retry behavior. after, if non-nil, is called
+// after every flushed chunk.
+func NewBulkProcessor(client *elasticsearch.Client, opts BulkProcessorOptions, after AfterFunc) *BulkProcessor {
+	if opts.Workers <= 0 {
+		opts.Workers = 1
+	}
+	p := &BulkProcessor{
+		client:   client,
+		opts:     opts,
+		after:    after,
+		flushSem: make(chan struct{}, opts.Workers),
+	}
+	if opts.FlushInterval > 0 {
+		p.timer = time.AfterFunc(opts.FlushInterval, p.flushOnTimer)
+	}
+	return p
+}
+
+// Add queues a document action, flushing synchronously if BulkActions or
+// BulkSize is exceeded.
+func (p *BulkProcessor) Add(req BulkableRequest) error {
+	if req.Action == "" {
+		req.Action = "index"
+	}
+	if err := validateAction(req.Action); err != nil {
+		return errors.ElasticsearchError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "invalid bulk action",
+			Details:    err,
+			Type:       errors.BadRequestError,
+		}
+	}
+
+	docJSON, err := json.Marshal(req.Doc)
+	if err != nil {
+		return errors.ElasticsearchError{
+			StatusCode: http.StatusBadRequest,
+			Message:    "failed to marshal document",
+			Details:    err,
+			Type:       errors.MarhshalingError,
+		}
+	}
+
+	p.mu.Lock()
+	p.queued = append(p.queued, req)
+	p.bufSize += len(docJSON)
+	shouldFlush := len(p.queued) >= p.opts.BulkActions || (p.opts.BulkSize > 0 && p.bufSize >= p.opts.BulkSize)
+	p.mu.Unlock()
+
+	if shouldFlush {
+		return p.Flush()
+	}
+	return nil
+}
+
+// flushOnTimer is invoked by the FlushInterval timer.
+func (p *BulkProcessor) flushOnTimer() {
+	_ = p.Flush()
+	p.mu.Lock()
+	closed := p.closed
+	p.mu.Unlock()
+	if !closed && p.opts.FlushInterval > 0 {
+		p.timer.Reset(p.opts.FlushInterval)
+	}
+}
+
+// Flush sends any queued items to Elasticsearch immediately.
+func (p *BulkProcessor) Flush() error {
+	p.mu.Lock()
+	if len(p.queued) == 0 {
+		p.mu.Unlock()
+		return nil
+	}
+	batch := p.queued
+	p.queued = nil
+	p.bufSize = 0
+	p.mu.Unlock()
+
+	p.flushSem <- struct{}{}
+	p.wg.Add(1)
+	defer func() {
+		<-p.flushSem
+		p.wg.Done()
+	}()
+
+	return p.send(batch, 0)
+}
+
+// Close flushes any remaining items and waits for in-flight flushes to
+// complete.
+func (p *BulkProcessor) Close() error {
+	p.mu.Lock()
+	p.closed = true
+	p.mu.Unlock()
+	if p.timer != nil {
+		p.timer.Stop()
+	}
+	err := p.Flush()
+	p.wg.Wait()
+	return err
+}
+
+// Stats returns a snapshot of the processor's cumulative counters.
+func (p *BulkProcessor) Stats() BulkStats {
+	p.statsMu.Lock()
+	defer p.statsMu.Unlock()
+	return p.stats
+}
+
+// send marshals batch into an NDJSON bulk body, issues the request, parses
+// per-item results, and retries only the items that failed with a retryable
+// status, up to Backoff.MaxRetries.
+func (p *BulkProcessor) send(batch []BulkableRequest, attempt int) error {
+	body, err := buildBulkBody(batch)
+	if err != nil {
+		return err
+	}
+
+	p.statsMu.Lock()
+	p.stats.Bytes += int64(body.Len())
+	p.statsMu.Unlock()
+
+	res, err := p.client.Bulk(bytes.NewReader(body.Bytes()), p.client.Bulk.WithContext(context.Background()))
+	if err != nil {
+		if p.after != nil {
+			p.after(batch, nil, err)
+		}
+		p.statsMu.Lock()
+		p.stats.Failed += int64(len(batch))
+		p.statsMu.Unlock()
+		return errors.NewConnectionError(err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		parseErr := errors.ParseElasticsearchError(res, "bulk request failed")
+		if p.after != nil {
+			p.after(batch, nil, parseErr)
+		}
+		p.statsMu.Lock()
+		p.stats.Failed += int64(len(batch))
+		p.statsMu.Unlock()
+		return parseErr
+	}
+
+	var bulkResp map[string]interface{}
+	if err := json.NewDecoder(res.Body).Decode(&bulkResp); err != nil {
+		return errors.ElasticsearchError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "failed to decode bulk response",
+			Details:    err,
+			Type:       errors.DecodeError,
+		}
+	}
+
+	if p.after != nil {
+		p.after(batch, bulkResp, nil)
+	}
+
+	retryable := retryableItems(batch, bulkResp)
+
+	// Items rejected with a non-retryable error status count as failed.
+	permanent := 0
+	if items, ok := bulkResp["items"].([]interface{}); ok {
+		for _, item := range items {
+			itemMap, _ := item.(map[string]interface{})
+			for _, result := range itemMap {
+				resultMap, _ := result.(map[string]interface{})
+				status, _ := resultMap["status"].(float64)
+				if status >= 300 && status != http.StatusTooManyRequests && status != http.StatusServiceUnavailable {
+					permanent++
+				}
+			}
+		}
+	}
+
+	succeeded := len(batch) - len(retryable) - permanent
+	p.statsMu.Lock()
+	p.stats.Indexed += int64(succeeded)
+	p.stats.Failed += int64(permanent)
+	p.statsMu.Unlock()
+
+	if len(retryable) == 0 {
+		return nil
+	}
+
+	if attempt >= p.opts.Backoff.MaxRetries {
+		p.statsMu.Lock()
+		p.stats.Failed += int64(len(retryable))
+		p.statsMu.Unlock()
+		return nil
+	}
+
+	p.statsMu.Lock()
+	p.stats.Retried += int64(len(retryable))
+	p.statsMu.Unlock()
+
+	time.Sleep(p.opts.Backoff.nextDelay(attempt))
+	return p.send(retryable, attempt+1)
+}
+
+// buildBulkBody marshals a batch of BulkableRequest into the NDJSON bulk
+// request body Elasticsearch expects.
+func buildBulkBody(batch []BulkableRequest) (*bytes.Buffer, error) {
+	var buf bytes.Buffer
+	for _, req := range batch {
+		action := req.Action
+		if action == "" {
+			action = "index"
+		}
+		meta := map[string]interface{}{
+			action: metaFor(req),
+		}
+		metaJSON, err := json.Marshal(meta)
+		if err != nil {
+			return nil, errors.ElasticsearchError{
+				StatusCode: http.StatusBadRequest,
+				Message:    "failed to marshal bulk metadata",
+				Details:    err,
+				Type:       errors.MarhshalingError,
+			}
+		}
+		buf.Write(metaJSON)
+		buf.WriteByte('\n')
+
+		if action == "delete" {
+			continue
+		}
+
+		doc := req.Doc
+		if action == "update" {
+			doc = map[string]interface{}{"doc": req.Doc}
+		}
+		docJSON, err := json.Marshal(doc)
+		if err != nil {
+			return nil, errors.ElasticsearchError{
+				StatusCode: http.StatusBadRequest,
+				Message:    "failed to marshal document",
+				Details:    err,
+				Type:       errors.MarhshalingError,
+			}
+		}
+		buf.Write(docJSON)
+		buf.WriteByte('\n')
+	}
+	return &buf, nil
+}
+
+// metaFor builds the action metadata object (_index/_id) for a request.
+func metaFor(req BulkableRequest) map[string]interface{} {
+	m := map[string]interface{}{"_index": req.Index}
+	if req.ID != "" {
+		m["_id"] = req.ID
+	}
+	return m
+}
+
+// retryableItems inspects the NDJSON bulk response and returns the subset of
+// batch whose corresponding item failed with a retryable status (429, 503).
+func retryableItems(batch []BulkableRequest, bulkResp map[string]interface{}) []BulkableRequest {
+	items, ok := bulkResp["items"].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	var retry []BulkableRequest
+	for i, item := range items {
+		if i >= len(batch) {
+			break
+		}
+		itemMap, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, result := range itemMap {
+			resultMap, ok := result.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			status, _ := resultMap["status"].(float64)
+			if status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable {
+				retry = append(retry, batch[i])
+			}
+		}
+	}
+	return retry
+}
+
+// ensure BulkableRequest actions are well-formed; returned error mirrors the
+// style used elsewhere in this package.
+func validateAction(action string) error {
+	switch action {
+	case "index", "create", "update", "delete":
+		return nil
+	default:
+		return fmt.Errorf("unsupported bulk action: %s", action)
+	}
+}
@@ -0,0 +1,60 @@
+// compat.go covers one specific 6.x/7.x/8.x wire difference this client
+// actually needs to parse: the shape of `hits.total` in a search response.
+// It is not a general multi-version transport — this package talks to
+// every supported cluster through a single
+// github.com/elastic/go-elasticsearch/v8 client, not per-version concrete
+// clients. Other cross-version concerns (typed vs typeless mappings,
+// compat headers, suggester payload shape) are handled, where they matter,
+// as a version gate in elastic.go/auto_complete.go rather than a parallel
+// client per major version; see New's doc comment for why
+// esc.DefaultMinVersion makes that gate sufficient today.
+
+package elastic
+
+import "github.com/goccy/go-json"
+
+// hitsTotal unmarshals Elasticsearch's `hits.total` field, which changed
+// shape between major versions: on 6.x it is a plain integer, while 7.x and
+// 8.x report `{"value": N, "relation": "eq"}`. Embedding this in place of a
+// bare int lets the typed responses in Search and SearchScroll decode either wire shape without
+// branching on esc.DetectedVersion() at every call site.
+type hitsTotal int
+
+// UnmarshalJSON accepts either a JSON number (6.x) or an object with a
+// "value" field (7.x/8.x).
+func (h *hitsTotal) UnmarshalJSON(data []byte) error {
+	if len(data) == 0 || string(data) == "null" {
+		return nil
+	}
+	if data[0] == '{' {
+		var obj struct {
+			Value int `json:"value"`
+		}
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return err
+		}
+		*h = hitsTotal(obj.Value)
+		return nil
+	}
+	var n int
+	if err := json.Unmarshal(data, &n); err != nil {
+		return err
+	}
+	*h = hitsTotal(n)
+	return nil
+}
+
+// totalFromHits extracts `hits.total` from an already-decoded
+// map[string]interface{} search response, handling both the 6.x (plain
+// number) and 7.x/8.x (`{"value": N}`) shapes.
+func totalFromHits(hits map[string]interface{}) (float64, bool) {
+	switch total := hits["total"].(type) {
+	case float64:
+		return total, true
+	case map[string]interface{}:
+		value, ok := total["value"].(float64)
+		return value, ok
+	default:
+		return 0, false
+	}
+}
@@ -3,17 +3,32 @@ package elastic
 import (
 	"context"
 	"github.com/elastic/go-elasticsearch/v8"
+	"go-es/internal/esc"
+	"go-es/internal/query/builder"
+	"go-es/internal/service/document/model/request"
 	"go-es/internal/service/document/model/response"
+	"io"
+	"time"
 )
 
 // Elastic is an interface to interact with Elasticsearch
 type Elastic interface {
-	// AddBatchData adds a batch of documents to an Elasticsearch index
-	AddBatchData(ctx context.Context, indexName string, data []map[string]interface{}) error
+	// BulkIndex indexes a batch of documents into an Elasticsearch index
+	// through BulkIngest's esutil.BulkIndexer, flushing by opts.FlushBytes
+	// and retrying individual documents that fail with a transient status
+	// (429, 502, 503, 504) or a connection error with exponential backoff.
+	// It reports how many documents were indexed and the details of any
+	// that failed permanently.
+	BulkIndex(ctx context.Context, indexName string, docs []map[string]interface{}, opts BulkOptions) (*BulkResult, error)
 	// Search runs a search query against an Elasticsearch index
 	Search(ctx context.Context, indexName string, payload []byte) (*response.SearchResponse, error)
-	// Suggest runs a suggest query against an Elasticsearch index
-	AutoComplete(ctx context.Context, indexName string, payload []byte) (*response.SuggestResponse, error)
+	// SearchQuery runs a search built with the query/builder package,
+	// sparing the caller from hand-marshaling a raw query body.
+	SearchQuery(ctx context.Context, indexName string, src builder.Sourcer) (*response.SearchResponse, error)
+	// AutoComplete runs a suggestion query against an Elasticsearch index,
+	// dispatching to the query-building and response-parsing strategy that
+	// matches mode (completion, edge_ngram, search_as_you_type, or phrase).
+	AutoComplete(ctx context.Context, indexName string, field string, input string, mode request.SuggestMode, opts SuggestOptions) (*response.SuggestResponse, error)
 	// DeleteDocument deletes a document from an Elasticsearch index
 	DeleteDocument(ctx context.Context, index string, docId string) error
 	// UpdateDocument updates a document in an Elasticsearch index
@@ -24,14 +39,52 @@ type Elastic interface {
 	ListAllDocuments(ctx context.Context, index string, query map[string]interface{}) (map[string]interface{}, error)
 	// ExportDocuments exports documents from an Elasticsearch index
 	ExportDocuments(ctx context.Context, index string, query map[string]interface{}) ([]map[string]interface{}, error)
-	// ImportDocuments imports documents to an Elasticsearch index
-	ImportDocuments(ctx context.Context, index string, documents []map[string]interface{}) error
+	// ExportDocumentsStream exports documents using a Point-in-Time +
+	// search_after cursor, streaming results on the returned channels instead
+	// of buffering the whole result set.
+	ExportDocumentsStream(ctx context.Context, index string, query map[string]interface{}, opts ExportOptions) (<-chan response.SearchDocument, <-chan error)
+	// ExportDocumentsEncoded drives ExportDocumentsStream and writes each
+	// document to w through enc, so the caller can pick NDJSON, a JSON
+	// array, or CSV without buffering the result set itself.
+	ExportDocumentsEncoded(ctx context.Context, index string, query map[string]interface{}, opts ExportOptions, enc Encoder, w io.Writer) (int64, error)
+	// ScrollDocuments streams an index's documents via the Scroll API,
+	// clearing the scroll context on completion or cancellation.
+	ScrollDocuments(ctx context.Context, index string, query map[string]interface{}, batchSize int, keepAlive time.Duration) (<-chan Batch, <-chan error)
+	// SearchScroll starts a scroll context for a caller-supplied query
+	// payload, returning the scroll ID and first page so callers can drive
+	// paging themselves through ScrollNext rather than consuming a channel.
+	SearchScroll(ctx context.Context, index string, payload []byte, keepAlive time.Duration) (scrollID string, page *response.SearchResponse, err error)
+	// ScrollNext advances a scroll started by SearchScroll to its next page.
+	ScrollNext(ctx context.Context, scrollID string, keepAlive time.Duration) (nextScrollID string, page *response.SearchResponse, err error)
+	// ClearScroll releases a scroll context before its keep-alive expires.
+	ClearScroll(ctx context.Context, scrollID string) error
+	// SearchAfter streams an index's documents via a Point-in-Time context
+	// and search_after, for deep pagination without a scroll context.
+	SearchAfter(ctx context.Context, index string, query map[string]interface{}, opts ExportOptions) (<-chan response.SearchDocument, <-chan error)
+	// ImportDocumentsStream parses r incrementally (NDJSON, bulk, or a
+	// single JSON array, per format) and pipelines the resulting documents
+	// into index through an esutil.BulkIndexer, applying backpressure via a
+	// bounded channel so the input is never fully materialized in memory.
+	ImportDocumentsStream(ctx context.Context, index string, r io.Reader, format request.ImportFormat, opts ImportOptions) (*ImportSummary, error)
+	// BulkIngest ingests a mixed batch of index/create/update/delete
+	// operations through an esutil.BulkIndexer, retrying transient per-item
+	// failures and reporting per-action counts plus permanent failures.
+	BulkIngest(ctx context.Context, index string, items []BulkIngestItem, opts BulkIngestOptions) (*BulkIngestSummary, error)
 }
 type elastic struct {
-	client *elasticsearch.Client
+	client  *elasticsearch.Client
+	version *esc.ClusterVersion
 }
 
-// New creates a new instance of Elastic.
+// New creates a new instance of Elastic. It captures the cluster version
+// most recently detected by esc.NewClientWithVersionCheck (nil if the check
+// was never run, e.g. in a test) as a hook for version-sensitive behavior,
+// used today as a gate: AutoComplete re-checks e.version and rejects
+// anything below esc.DefaultMinVersion before sending a suggester payload
+// the connected cluster may not understand. Search and its callers decode
+// `hits.total` via hitsTotal, which accepts both the 6.x plain-integer
+// shape and the 7.x/8.x `{"value": N}` shape by sniffing the JSON itself,
+// so that path needs no version branch at all, pre- or post-7.0.
 func New(client *elasticsearch.Client) Elastic {
-	return &elastic{client: client}
+	return &elastic{client: client, version: esc.DetectedVersion()}
 }
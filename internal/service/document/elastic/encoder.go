@@ -0,0 +1,120 @@
+package elastic
+
+import (
+	"encoding/csv"
+	"github.com/goccy/go-json"
+	"go-es/internal/service/document/model/response"
+	"io"
+)
+
+// Encoder writes a stream of exported documents to w in a particular wire
+// format. WriteHeader/WriteFooter bracket the stream (e.g. the opening/
+// closing "[" "]" of a JSON array) and are no-ops for formats that don't
+// need framing.
+type Encoder interface {
+	WriteHeader(w io.Writer) error
+	WriteDoc(w io.Writer, doc response.SearchDocument) error
+	WriteFooter(w io.Writer) error
+}
+
+// NDJSONEncoder writes one JSON object per line, the default export format.
+type NDJSONEncoder struct{}
+
+func (NDJSONEncoder) WriteHeader(io.Writer) error { return nil }
+
+func (NDJSONEncoder) WriteDoc(w io.Writer, doc response.SearchDocument) error {
+	line, err := json.Marshal(doc.Data)
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(line); err != nil {
+		return err
+	}
+	_, err = w.Write([]byte("\n"))
+	return err
+}
+
+func (NDJSONEncoder) WriteFooter(io.Writer) error { return nil }
+
+// JSONArrayEncoder writes documents as a single top-level JSON array,
+// comma-separating entries as they arrive.
+type JSONArrayEncoder struct {
+	wrote bool
+}
+
+func (*JSONArrayEncoder) WriteHeader(w io.Writer) error {
+	_, err := w.Write([]byte("["))
+	return err
+}
+
+func (e *JSONArrayEncoder) WriteDoc(w io.Writer, doc response.SearchDocument) error {
+	if e.wrote {
+		if _, err := w.Write([]byte(",")); err != nil {
+			return err
+		}
+	}
+	e.wrote = true
+	line, err := json.Marshal(doc.Data)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(line)
+	return err
+}
+
+func (*JSONArrayEncoder) WriteFooter(w io.Writer) error {
+	_, err := w.Write([]byte("]"))
+	return err
+}
+
+// CSVEncoder writes documents as CSV rows using a caller-supplied column
+// list; missing fields are written as empty cells.
+type CSVEncoder struct {
+	Columns []string
+	writer  *csv.Writer
+}
+
+func (e *CSVEncoder) WriteHeader(w io.Writer) error {
+	e.writer = csv.NewWriter(w)
+	if err := e.writer.Write(e.Columns); err != nil {
+		return err
+	}
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+func (e *CSVEncoder) WriteDoc(w io.Writer, doc response.SearchDocument) error {
+	row := make([]string, len(e.Columns))
+	for i, col := range e.Columns {
+		if v, ok := doc.Data[col]; ok {
+			row[i] = toCSVCell(v)
+		}
+	}
+	if err := e.writer.Write(row); err != nil {
+		return err
+	}
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+func (e *CSVEncoder) WriteFooter(io.Writer) error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+// toCSVCell renders an arbitrary document field as a CSV cell, marshaling
+// non-scalar values to JSON rather than dropping them.
+func toCSVCell(v interface{}) string {
+	switch val := v.(type) {
+	case string:
+		return val
+	case nil:
+		return ""
+	default:
+		b, err := json.Marshal(val)
+		if err != nil {
+			return ""
+		}
+		return string(b)
+	}
+}
@@ -56,12 +56,7 @@ func (e *elastic) ExportDocuments(ctx context.Context, index string, query map[s
 		e.client.Search.WithScroll(MaxScrollTime), // Add scroll context for larger datasets
 	)
 	if err != nil {
-		return nil, errors.ElasticsearchError{
-			StatusCode: http.StatusInternalServerError,
-			Message:    "failed to connect to elastic server",
-			Details:    err,
-			Type:       errors.ConnectionError,
-		}
+		return nil, errors.NewConnectionError(err)
 	}
 	defer res.Body.Close()
 
@@ -80,7 +75,7 @@ func (e *elastic) ExportDocuments(ctx context.Context, index string, query map[s
 	}
 
 	// Get total hits
-	totalHits, ok := searchResult["hits"].(map[string]interface{})["total"].(map[string]interface{})["value"].(float64)
+	totalHits, ok := totalFromHits(searchResult["hits"].(map[string]interface{}))
 	if !ok {
 		return nil, errors.ElasticsearchError{
 			StatusCode: http.StatusInternalServerError,
@@ -119,12 +114,7 @@ func (e *elastic) ExportDocuments(ctx context.Context, index string, query map[s
 			e.client.Scroll.WithScroll(MaxScrollTime),
 		)
 		if err != nil {
-			return nil, errors.ElasticsearchError{
-				StatusCode: http.StatusInternalServerError,
-				Message:    "failed to connect to elastic server",
-				Details:    err,
-				Type:       errors.ConnectionError,
-			}
+			return nil, errors.NewConnectionError(err)
 		}
 		defer res.Body.Close()
 
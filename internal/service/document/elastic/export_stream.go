@@ -0,0 +1,219 @@
+package elastic
+
+import (
+	"bytes"
+	"context"
+	"github.com/goccy/go-json"
+	"go-es/internal/errors"
+	"go-es/internal/service/document/model/response"
+	"io"
+	"net/http"
+	"time"
+)
+
+// DefaultPITKeepAlive is the keep-alive duration used for a Point-in-Time
+// context when the caller does not supply one.
+const DefaultPITKeepAlive = 1 * time.Minute
+
+// ExportOptions configures an ExportDocumentsStream call.
+type ExportOptions struct {
+	BatchSize int           // number of hits requested per search_after page
+	KeepAlive time.Duration // PIT keep-alive
+}
+
+// DefaultExportOptions returns sane defaults for ExportOptions.
+func DefaultExportOptions() ExportOptions {
+	return ExportOptions{
+		BatchSize: 1000,
+		KeepAlive: DefaultPITKeepAlive,
+	}
+}
+
+// ExportDocumentsStream exports documents from an Elasticsearch index using a
+// Point-in-Time (PIT) context and search_after, replacing the scroll-based
+// ExportDocuments for large exports.
+//
+// It opens a PIT via the _pit endpoint, issues searches sorted by
+// _shard_doc (a stable tiebreaker within a PIT), and advances the search_after
+// cursor until a page comes back empty, closing the PIT in a deferred
+// cleanup. Documents and errors are delivered on the returned channels so
+// the caller (typically an HTTP handler) can stream them without buffering
+// the whole result set.
+func (e *elastic) ExportDocumentsStream(ctx context.Context, index string, query map[string]interface{}, opts ExportOptions) (<-chan response.SearchDocument, <-chan error) {
+	docs := make(chan response.SearchDocument)
+	errs := make(chan error, 1)
+
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultExportOptions().BatchSize
+	}
+	if opts.KeepAlive <= 0 {
+		opts.KeepAlive = DefaultExportOptions().KeepAlive
+	}
+
+	go func() {
+		defer close(docs)
+		defer close(errs)
+
+		pitRes, err := e.client.OpenPointInTime(
+			[]string{index},
+			opts.KeepAlive.String(),
+			e.client.OpenPointInTime.WithContext(ctx),
+		)
+		if err != nil {
+			errs <- errors.ElasticsearchError{
+				StatusCode: http.StatusInternalServerError,
+				Message:    "failed to open point-in-time",
+				Details:    err,
+				Type:       errors.ConnectionError,
+			}
+			return
+		}
+
+		var pitResult struct {
+			ID string `json:"id"`
+		}
+		decodeErr := json.NewDecoder(pitRes.Body).Decode(&pitResult)
+		pitRes.Body.Close()
+		if decodeErr != nil {
+			errs <- errors.ElasticsearchError{
+				StatusCode: http.StatusInternalServerError,
+				Message:    "failed to decode point-in-time response",
+				Details:    decodeErr,
+				Type:       errors.DecodeError,
+			}
+			return
+		}
+
+		pitID := pitResult.ID
+		defer func() {
+			_, _ = e.client.ClosePointInTime(
+				e.client.ClosePointInTime.WithContext(ctx),
+				e.client.ClosePointInTime.WithBody(jsonBody(map[string]interface{}{"id": pitID})),
+			)
+		}()
+
+		if query == nil {
+			query = map[string]interface{}{"match_all": map[string]interface{}{}}
+		}
+
+		var searchAfter []interface{}
+		for {
+			body := map[string]interface{}{
+				"size":  opts.BatchSize,
+				"query": query,
+				"sort": []map[string]interface{}{
+					{"_shard_doc": "asc"},
+				},
+				"pit": map[string]interface{}{
+					"id":         pitID,
+					"keep_alive": opts.KeepAlive.String(),
+				},
+			}
+			if searchAfter != nil {
+				body["search_after"] = searchAfter
+			}
+
+			res, err := e.client.Search(
+				e.client.Search.WithContext(ctx),
+				e.client.Search.WithBody(jsonBody(body)),
+			)
+			if err != nil {
+				errs <- errors.NewConnectionError(err)
+				return
+			}
+
+			if res.IsError() {
+				err := errors.ParseElasticsearchError(res, "failed to search documents for export")
+				res.Body.Close()
+				errs <- err
+				return
+			}
+
+			var page struct {
+				PitID string `json:"pit_id"`
+				Hits  struct {
+					Hits []struct {
+						ID     string                 `json:"_id"`
+						Score  float64                `json:"_score"`
+						Source map[string]interface{} `json:"_source"`
+						Sort   []interface{}          `json:"sort"`
+					} `json:"hits"`
+				} `json:"hits"`
+			}
+			decErr := json.NewDecoder(res.Body).Decode(&page)
+			res.Body.Close()
+			if decErr != nil {
+				errs <- errors.ElasticsearchError{
+					StatusCode: http.StatusInternalServerError,
+					Message:    "failed to decode elastic response",
+					Details:    decErr,
+					Type:       errors.DecodeError,
+				}
+				return
+			}
+
+			if len(page.Hits.Hits) == 0 {
+				return
+			}
+			if page.PitID != "" {
+				pitID = page.PitID
+			}
+
+			for _, hit := range page.Hits.Hits {
+				select {
+				case docs <- response.SearchDocument{ID: hit.ID, Score: hit.Score, Data: hit.Source}:
+				case <-ctx.Done():
+					errs <- ctx.Err()
+					return
+				}
+				searchAfter = hit.Sort
+			}
+		}
+	}()
+
+	return docs, errs
+}
+
+// jsonBody marshals v and wraps it in a reader, swallowing marshal errors
+// since the inputs here are always built from static maps.
+func jsonBody(v interface{}) io.Reader {
+	b, _ := json.Marshal(v)
+	return bytes.NewReader(b)
+}
+
+// ExportDocumentsEncoded drives ExportDocumentsStream and writes each
+// document to w through enc as it arrives, returning the number of
+// documents written. It's the non-HTTP-specific core of a streaming
+// export; handlers only need to pick an Encoder and flush w.
+func (e *elastic) ExportDocumentsEncoded(ctx context.Context, index string, query map[string]interface{}, opts ExportOptions, enc Encoder, w io.Writer) (int64, error) {
+	if err := enc.WriteHeader(w); err != nil {
+		return 0, err
+	}
+
+	docs, errs := e.ExportDocumentsStream(ctx, index, query, opts)
+
+	var count int64
+	for docs != nil || errs != nil {
+		select {
+		case doc, ok := <-docs:
+			if !ok {
+				docs = nil
+				continue
+			}
+			if err := enc.WriteDoc(w, doc); err != nil {
+				return count, err
+			}
+			count++
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			if err != nil {
+				return count, err
+			}
+		}
+	}
+
+	return count, enc.WriteFooter(w)
+}
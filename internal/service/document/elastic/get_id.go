@@ -15,12 +15,7 @@ func (e *elastic) GetDocumentByID(ctx context.Context, index string, docID strin
 	// Get document
 	res, err := e.client.Get(index, docID, e.client.Get.WithContext(ctx))
 	if err != nil {
-		return nil, errors.ElasticsearchError{
-			StatusCode: http.StatusInternalServerError,
-			Message:    "failed to connect to elastic server",
-			Details:    err,
-			Type:       errors.ConnectionError,
-		}
+		return nil, errors.NewConnectionError(err)
 	}
 	defer res.Body.Close()
 	if res.IsError() {
@@ -0,0 +1,272 @@
+package elastic
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/elastic/go-elasticsearch/v8/esutil"
+	"github.com/goccy/go-json"
+	"go-es/internal/errors"
+	"go-es/internal/service/document/model/request"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// importQueueSize bounds the channel between the scanner goroutine and the
+// esutil.BulkIndexer consumer, so a multi-GB import applies backpressure to
+// the scanner instead of buffering the whole file in memory.
+const importQueueSize = 256
+
+// ImportOptions controls the concurrency and flush behavior of
+// ImportDocumentsStream's underlying esutil.BulkIndexer.
+type ImportOptions struct {
+	NumWorkers    int // number of concurrent flush workers, 0 uses esutil's default (runtime.NumCPU())
+	FlushBytes    int // flush once the buffered request body reaches this size, 0 uses esutil's default (5MB)
+	FlushInterval int // flush interval in milliseconds, 0 uses esutil's default (30s)
+}
+
+// DefaultImportOptions returns sane defaults for ImportOptions.
+func DefaultImportOptions() ImportOptions {
+	return ImportOptions{
+		NumWorkers: 4,
+		FlushBytes: 5 << 20,
+	}
+}
+
+// ImportFailure reports a single document that could not be parsed or
+// indexed.
+type ImportFailure struct {
+	Line  int    `json:"line,omitempty"`
+	ID    string `json:"id,omitempty"`
+	Error string `json:"error"`
+}
+
+// ImportSummary reports the outcome of an ImportDocumentsStream call.
+type ImportSummary struct {
+	Indexed  int64           `json:"indexed"`
+	Failed   int64           `json:"failed"`
+	TookMs   int64           `json:"took_ms"`
+	Failures []ImportFailure `json:"failures,omitempty"`
+}
+
+// importedLine pairs a parsed document with its 1-indexed position in the
+// input, so a later indexing failure can be reported against the line that
+// produced it.
+type importedLine struct {
+	line int
+	doc  map[string]interface{}
+	err  error
+}
+
+// ImportDocumentsStream parses r incrementally according to format and
+// pipelines the resulting documents into index through an
+// esutil.BulkIndexer. Parsing happens in its own goroutine, handing parsed
+// documents to the indexing loop over a bounded channel: once the channel
+// fills, the scanner blocks until the indexer catches up, so a multi-GB
+// upload is never fully materialized in memory.
+func (e *elastic) ImportDocumentsStream(ctx context.Context, index string, r io.Reader, format request.ImportFormat, opts ImportOptions) (*ImportSummary, error) {
+	start := time.Now()
+
+	bi, err := esutil.NewBulkIndexer(esutil.BulkIndexerConfig{
+		Index:         index,
+		Client:        e.client,
+		NumWorkers:    opts.NumWorkers,
+		FlushBytes:    opts.FlushBytes,
+		FlushInterval: toDuration(opts.FlushInterval),
+	})
+	if err != nil {
+		return nil, errors.ElasticsearchError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "failed to create bulk indexer",
+			Details:    err,
+			Type:       errors.ServerError,
+		}
+	}
+
+	lines := make(chan importedLine, importQueueSize)
+	go scanImportStream(r, format, lines)
+
+	var (
+		mu       sync.Mutex
+		failures []ImportFailure
+		indexed  int64
+	)
+
+	for parsed := range lines {
+		if parsed.err != nil {
+			mu.Lock()
+			failures = append(failures, ImportFailure{Line: parsed.line, Error: parsed.err.Error()})
+			mu.Unlock()
+			continue
+		}
+
+		docJSON, err := json.Marshal(parsed.doc)
+		if err != nil {
+			mu.Lock()
+			failures = append(failures, ImportFailure{Line: parsed.line, Error: err.Error()})
+			mu.Unlock()
+			continue
+		}
+
+		line := parsed.line
+		id, _ := parsed.doc["id"].(string)
+
+		item := esutil.BulkIndexerItem{
+			Action:     "index",
+			DocumentID: id,
+			Body:       bytes.NewReader(docJSON),
+			OnSuccess: func(_ context.Context, _ esutil.BulkIndexerItem, _ esutil.BulkIndexerResponseItem) {
+				atomic.AddInt64(&indexed, 1)
+			},
+			OnFailure: func(_ context.Context, _ esutil.BulkIndexerItem, res esutil.BulkIndexerResponseItem, err error) {
+				reason := ""
+				if res.Error.Type != "" {
+					reason = fmt.Sprintf("%s: %s", res.Error.Type, res.Error.Reason)
+				} else if err != nil {
+					reason = err.Error()
+				}
+				mu.Lock()
+				failures = append(failures, ImportFailure{Line: line, ID: id, Error: reason})
+				mu.Unlock()
+			},
+		}
+
+		if err := bi.Add(ctx, item); err != nil {
+			_ = bi.Close(ctx)
+			return nil, errors.ElasticsearchError{
+				StatusCode: http.StatusInternalServerError,
+				Message:    "failed to queue import item",
+				Details:    err,
+				Type:       errors.ServerError,
+			}
+		}
+	}
+
+	if err := bi.Close(ctx); err != nil {
+		return nil, errors.ElasticsearchError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "failed to flush bulk indexer",
+			Details:    err,
+			Type:       errors.ServerError,
+		}
+	}
+
+	summary := &ImportSummary{
+		Indexed:  atomic.LoadInt64(&indexed),
+		Failures: failures,
+		TookMs:   time.Since(start).Milliseconds(),
+	}
+	summary.Failed = int64(len(summary.Failures))
+
+	// The caller is responsible for publishing a change-feed event on
+	// success (see handler.ImportDocuments).
+
+	return summary, nil
+}
+
+// scanImportStream parses r according to format, sending each document (or
+// parse error) on out, and closes out once r is exhausted.
+func scanImportStream(r io.Reader, format request.ImportFormat, out chan<- importedLine) {
+	defer close(out)
+
+	switch format {
+	case request.ImportFormatBulk:
+		scanBulkStream(r, out)
+	case request.ImportFormatJSON:
+		scanJSONArrayStream(r, out)
+	default:
+		scanNDJSONStream(r, out)
+	}
+}
+
+// scanNDJSONStream reads one JSON document per line.
+func scanNDJSONStream(r io.Reader, out chan<- importedLine) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		raw := bytes.TrimSpace(scanner.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+		var doc map[string]interface{}
+		if err := json.Unmarshal(raw, &doc); err != nil {
+			out <- importedLine{line: line, err: fmt.Errorf("invalid NDJSON document: %w", err)}
+			continue
+		}
+		out <- importedLine{line: line, doc: doc}
+	}
+	if err := scanner.Err(); err != nil {
+		out <- importedLine{line: line, err: fmt.Errorf("failed to read import stream: %w", err)}
+	}
+}
+
+// scanBulkStream reads Elasticsearch bulk format: a metadata line followed
+// by a document line, repeated.
+func scanBulkStream(r io.Reader, out chan<- importedLine) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		metaLine := bytes.TrimSpace(scanner.Bytes())
+		if len(metaLine) == 0 {
+			continue
+		}
+
+		var meta map[string]interface{}
+		if err := json.Unmarshal(metaLine, &meta); err != nil {
+			out <- importedLine{line: line, err: fmt.Errorf("invalid metadata line in bulk JSON: %w", err)}
+			continue
+		}
+		if _, ok := meta["index"]; !ok {
+			out <- importedLine{line: line, err: fmt.Errorf("metadata line must contain an 'index' field")}
+			continue
+		}
+
+		if !scanner.Scan() {
+			out <- importedLine{line: line, err: fmt.Errorf("missing document line in bulk JSON")}
+			return
+		}
+		line++
+		var doc map[string]interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &doc); err != nil {
+			out <- importedLine{line: line, err: fmt.Errorf("invalid document line in bulk JSON: %w", err)}
+			continue
+		}
+		out <- importedLine{line: line, doc: doc}
+	}
+	if err := scanner.Err(); err != nil {
+		out <- importedLine{line: line, err: fmt.Errorf("failed to read import stream: %w", err)}
+	}
+}
+
+// scanJSONArrayStream reads a single top-level JSON array of documents
+// incrementally via json.Decoder, so the whole array is never held as a
+// []byte or a parsed []map before indexing starts.
+func scanJSONArrayStream(r io.Reader, out chan<- importedLine) {
+	dec := json.NewDecoder(r)
+
+	if _, err := dec.Token(); err != nil {
+		out <- importedLine{line: 0, err: fmt.Errorf("invalid JSON array: %w", err)}
+		return
+	}
+
+	elem := 0
+	for dec.More() {
+		elem++
+		var doc map[string]interface{}
+		if err := dec.Decode(&doc); err != nil {
+			out <- importedLine{line: elem, err: fmt.Errorf("invalid JSON array element: %w", err)}
+			return
+		}
+		out <- importedLine{line: elem, doc: doc}
+	}
+}
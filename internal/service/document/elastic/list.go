@@ -20,12 +20,7 @@ func (e *elastic) ListAllDocuments(ctx context.Context, index string, query map[
 	)
 	if err != nil {
 		// Return a connection error if the request fails
-		return nil, errors.ElasticsearchError{
-			StatusCode: http.StatusInternalServerError,
-			Message:    "failed to connect to elastic server",
-			Details:    err,
-			Type:       errors.ConnectionError,
-		}
+		return nil, errors.NewConnectionError(err)
 	}
 	defer res.Body.Close()
 
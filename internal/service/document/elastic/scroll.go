@@ -0,0 +1,140 @@
+package elastic
+
+import (
+	"context"
+	"go-es/internal/errors"
+	"go-es/internal/service/document/model/response"
+	"net/http"
+	"time"
+
+	"github.com/goccy/go-json"
+)
+
+// DefaultScrollBatchSize and DefaultScrollKeepAlive are used by
+// ScrollDocuments when the caller does not supply its own.
+const (
+	DefaultScrollBatchSize = 1000
+	DefaultScrollKeepAlive = 1 * time.Minute
+)
+
+// Batch is a page of documents delivered by ScrollDocuments.
+type Batch struct {
+	Documents []response.SearchDocument
+}
+
+// ScrollDocuments streams an index's documents using the (deprecated but
+// still widely used) Scroll API: it opens a scroll context with the initial
+// `_search`, repeatedly advances it via `_search/scroll`, and clears it in a
+// deferred cleanup so the context is released even if ctx is canceled
+// mid-scroll.
+func (e *elastic) ScrollDocuments(ctx context.Context, index string, query map[string]interface{}, batchSize int, keepAlive time.Duration) (<-chan Batch, <-chan error) {
+	batches := make(chan Batch)
+	errs := make(chan error, 1)
+
+	if batchSize <= 0 {
+		batchSize = DefaultScrollBatchSize
+	}
+	if keepAlive <= 0 {
+		keepAlive = DefaultScrollKeepAlive
+	}
+
+	go func() {
+		defer close(batches)
+		defer close(errs)
+
+		if query == nil {
+			query = map[string]interface{}{"match_all": map[string]interface{}{}}
+		}
+
+		res, err := e.client.Search(
+			e.client.Search.WithContext(ctx),
+			e.client.Search.WithIndex(index),
+			e.client.Search.WithBody(jsonBody(map[string]interface{}{"size": batchSize, "query": query})),
+			e.client.Search.WithScroll(keepAlive),
+		)
+		if err != nil {
+			errs <- errors.NewConnectionError(err)
+			return
+		}
+
+		var scrollID string
+		defer func() {
+			if scrollID == "" {
+				return
+			}
+			// Use a fresh context so the scroll is cleared even if ctx was
+			// canceled mid-scroll.
+			_, _ = e.client.ClearScroll(
+				e.client.ClearScroll.WithContext(context.Background()),
+				e.client.ClearScroll.WithScrollID(scrollID),
+			)
+		}()
+
+		for {
+			if res.IsError() {
+				scrollErr := errors.ParseElasticsearchError(res, "failed to scroll documents")
+				res.Body.Close()
+				errs <- scrollErr
+				return
+			}
+
+			var page struct {
+				ScrollID string `json:"_scroll_id"`
+				Hits     struct {
+					Hits []struct {
+						ID     string                 `json:"_id"`
+						Score  float64                `json:"_score"`
+						Source map[string]interface{} `json:"_source"`
+					} `json:"hits"`
+				} `json:"hits"`
+			}
+			decErr := json.NewDecoder(res.Body).Decode(&page)
+			res.Body.Close()
+			if decErr != nil {
+				errs <- errors.ElasticsearchError{
+					StatusCode: http.StatusInternalServerError,
+					Message:    "failed to decode elastic response",
+					Details:    decErr,
+					Type:       errors.DecodeError,
+				}
+				return
+			}
+
+			scrollID = page.ScrollID
+			if len(page.Hits.Hits) == 0 {
+				return
+			}
+
+			batch := Batch{Documents: make([]response.SearchDocument, 0, len(page.Hits.Hits))}
+			for _, hit := range page.Hits.Hits {
+				batch.Documents = append(batch.Documents, response.SearchDocument{ID: hit.ID, Score: hit.Score, Data: hit.Source})
+			}
+
+			select {
+			case batches <- batch:
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			}
+
+			res, err = e.client.Scroll(
+				e.client.Scroll.WithContext(ctx),
+				e.client.Scroll.WithScrollID(scrollID),
+				e.client.Scroll.WithScroll(keepAlive),
+			)
+			if err != nil {
+				errs <- errors.NewConnectionError(err)
+				return
+			}
+		}
+	}()
+
+	return batches, errs
+}
+
+// SearchAfter streams an index's documents via a Point-in-Time context and
+// search_after, for deep pagination without holding a scroll context open.
+// It shares its implementation with ExportDocumentsStream.
+func (e *elastic) SearchAfter(ctx context.Context, index string, query map[string]interface{}, opts ExportOptions) (<-chan response.SearchDocument, <-chan error) {
+	return e.ExportDocumentsStream(ctx, index, query, opts)
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"github.com/goccy/go-json"
 	"go-es/internal/errors"
+	"go-es/internal/query/builder"
 	"go-es/internal/service/document/model/response"
 	"net/http"
 )
@@ -21,12 +22,7 @@ func (e *elastic) Search(ctx context.Context, indexName string, payload []byte)
 		e.client.Search.WithBody(bytes.NewReader(payload)),
 	)
 	if err != nil {
-		return nil, errors.ElasticsearchError{
-			StatusCode: http.StatusInternalServerError,
-			Message:    "failed to connect to elastic server",
-			Details:    err,
-			Type:       errors.ConnectionError,
-		}
+		return nil, errors.NewConnectionError(err)
 	}
 	defer res.Body.Close()
 
@@ -38,16 +34,15 @@ func (e *elastic) Search(ctx context.Context, indexName string, payload []byte)
 	// Define a structure to parse the search response
 	var esResponse struct {
 		Hits struct {
-			Total struct {
-				Value int `json:"value"`
-			} `json:"total"`
-			MaxScore float64 `json:"max_score"`
+			Total    hitsTotal `json:"total"`
+			MaxScore float64   `json:"max_score"`
 			Hits     []struct {
 				ID     string                 `json:"_id"`
 				Score  float64                `json:"_score"`
 				Source map[string]interface{} `json:"_source"`
 			} `json:"hits"`
 		} `json:"hits"`
+		Aggregations map[string]interface{} `json:"aggregations"`
 	}
 
 	// Decode the search response JSON into the esResponse structure
@@ -62,9 +57,10 @@ func (e *elastic) Search(ctx context.Context, indexName string, payload []byte)
 
 	// Transform esResponse into SearchResponse
 	searchResponse := &response.SearchResponse{
-		Total:     esResponse.Hits.Total.Value,
-		MaxScore:  esResponse.Hits.MaxScore,
-		Documents: make([]response.SearchDocument, 0, len(esResponse.Hits.Hits)),
+		Total:        int(esResponse.Hits.Total),
+		MaxScore:     esResponse.Hits.MaxScore,
+		Documents:    make([]response.SearchDocument, 0, len(esResponse.Hits.Hits)),
+		Aggregations: esResponse.Aggregations,
 	}
 
 	// Iterate over the hits and append each document to the search response
@@ -78,3 +74,30 @@ func (e *elastic) Search(ctx context.Context, indexName string, payload []byte)
 
 	return searchResponse, nil
 }
+
+// SearchQuery performs a search built with the query/builder package
+// instead of a hand-marshaled payload, and otherwise behaves exactly like
+// Search.
+func (e *elastic) SearchQuery(ctx context.Context, indexName string, src builder.Sourcer) (*response.SearchResponse, error) {
+	body, err := src.Source()
+	if err != nil {
+		return nil, errors.ElasticsearchError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "failed to build search query",
+			Details:    err,
+			Type:       errors.MarhshalingError,
+		}
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, errors.ElasticsearchError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "failed to marshal search query",
+			Details:    err,
+			Type:       errors.MarhshalingError,
+		}
+	}
+
+	return e.Search(ctx, indexName, payload)
+}
@@ -0,0 +1,137 @@
+package elastic
+
+import (
+	"bytes"
+	"context"
+	"github.com/goccy/go-json"
+	"go-es/internal/errors"
+	"go-es/internal/service/document/model/response"
+	"io"
+	"net/http"
+	"time"
+)
+
+// scrollPage decodes an Elasticsearch `_search`/`_search/scroll` response
+// body into a scroll ID plus a SearchResponse page.
+func scrollPage(body []byte) (string, *response.SearchResponse, error) {
+	var esResponse struct {
+		ScrollID string `json:"_scroll_id"`
+		Hits     struct {
+			Total    hitsTotal `json:"total"`
+			MaxScore float64   `json:"max_score"`
+			Hits     []struct {
+				ID     string                 `json:"_id"`
+				Score  float64                `json:"_score"`
+				Source map[string]interface{} `json:"_source"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+
+	if err := json.Unmarshal(body, &esResponse); err != nil {
+		return "", nil, errors.ElasticsearchError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "failed to decode search response",
+			Details:    err,
+			Type:       errors.DecodeError,
+		}
+	}
+
+	page := &response.SearchResponse{
+		Total:     int(esResponse.Hits.Total),
+		MaxScore:  esResponse.Hits.MaxScore,
+		Documents: make([]response.SearchDocument, 0, len(esResponse.Hits.Hits)),
+	}
+	for _, hit := range esResponse.Hits.Hits {
+		page.Documents = append(page.Documents, response.SearchDocument{ID: hit.ID, Score: hit.Score, Data: hit.Source})
+	}
+
+	return esResponse.ScrollID, page, nil
+}
+
+// SearchScroll starts a scroll context for query against index, returning
+// the scroll ID (for ScrollNext/ClearScroll) alongside the first page of
+// results.
+func (e *elastic) SearchScroll(ctx context.Context, index string, payload []byte, keepAlive time.Duration) (string, *response.SearchResponse, error) {
+	if keepAlive <= 0 {
+		keepAlive = DefaultScrollKeepAlive
+	}
+
+	res, err := e.client.Search(
+		e.client.Search.WithContext(ctx),
+		e.client.Search.WithIndex(index),
+		e.client.Search.WithBody(bytes.NewReader(payload)),
+		e.client.Search.WithScroll(keepAlive),
+	)
+	if err != nil {
+		return "", nil, errors.NewConnectionError(err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return "", nil, errors.ParseElasticsearchError(res, "failed to start scroll")
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", nil, errors.ElasticsearchError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "failed to read search response",
+			Details:    err,
+			Type:       errors.DecodeError,
+		}
+	}
+
+	return scrollPage(body)
+}
+
+// ScrollNext advances scrollID to its next page via `_search/scroll`.
+func (e *elastic) ScrollNext(ctx context.Context, scrollID string, keepAlive time.Duration) (string, *response.SearchResponse, error) {
+	if keepAlive <= 0 {
+		keepAlive = DefaultScrollKeepAlive
+	}
+
+	res, err := e.client.Scroll(
+		e.client.Scroll.WithContext(ctx),
+		e.client.Scroll.WithScrollID(scrollID),
+		e.client.Scroll.WithScroll(keepAlive),
+	)
+	if err != nil {
+		return "", nil, errors.NewConnectionError(err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return "", nil, errors.ParseElasticsearchError(res, "failed to advance scroll")
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", nil, errors.ElasticsearchError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "failed to read scroll response",
+			Details:    err,
+			Type:       errors.DecodeError,
+		}
+	}
+
+	return scrollPage(body)
+}
+
+// ClearScroll releases the scroll context for scrollID before its
+// keep-alive would otherwise expire it.
+func (e *elastic) ClearScroll(ctx context.Context, scrollID string) error {
+	res, err := e.client.ClearScroll(
+		e.client.ClearScroll.WithContext(ctx),
+		e.client.ClearScroll.WithScrollID(scrollID),
+	)
+	if err != nil {
+		return errors.NewConnectionError(err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.ParseElasticsearchError(res, "failed to clear scroll")
+	}
+
+	return nil
+}
@@ -0,0 +1,246 @@
+package elastic
+
+import "go-es/internal/service/document/model/request"
+
+// SuggestOptions carries the query-time tuning knobs for a suggestion
+// request: fuzziness (completion/phrase), minimum input length
+// (edge_ngram/search_as_you_type), context-suggester filters (completion
+// only), and phrase-suggester tuning (phrase only).
+type SuggestOptions struct {
+	Fuzziness            string
+	FuzzyTranspositions  *bool
+	FuzzyPrefixLength    int
+	MinLength            int
+	ContextCategory      string
+	ContextGeo           *request.GeoPoint
+	Contexts             map[string][]string
+	Confidence           float64
+	MaxErrors            float64
+	DirectGeneratorField string
+}
+
+// SuggestStrategy builds the Elasticsearch query body for a suggestion
+// request and parses the corresponding response shape back into plain
+// suggestion strings. There's one implementation per request.SuggestMode,
+// because completion/phrase use the `suggest` API while edge_ngram/
+// search_as_you_type are plain field queries against `hits`.
+type SuggestStrategy interface {
+	BuildQuery(field, input string, opts SuggestOptions) map[string]interface{}
+	ParseSuggestions(parsed map[string]interface{}, field string) []string
+}
+
+// strategyFor returns the SuggestStrategy for mode, defaulting to the
+// completion suggester if mode is empty or unrecognized.
+func strategyFor(mode request.SuggestMode) SuggestStrategy {
+	switch mode {
+	case request.SuggestModeEdgeNgram:
+		return edgeNgramStrategy{}
+	case request.SuggestModeSearchAsYouType:
+		return searchAsYouTypeStrategy{}
+	case request.SuggestModePhrase:
+		return phraseStrategy{}
+	default:
+		return completionStrategy{}
+	}
+}
+
+// completionStrategy queries the completion suggester on field+".suggest",
+// optionally narrowed by fuzziness and category/geo context filters.
+type completionStrategy struct{}
+
+func (completionStrategy) BuildQuery(field, input string, opts SuggestOptions) map[string]interface{} {
+	completion := map[string]interface{}{
+		"field": field + ".suggest",
+	}
+	if opts.Fuzziness != "" {
+		fuzzy := map[string]interface{}{"fuzziness": opts.Fuzziness}
+		if opts.FuzzyTranspositions != nil {
+			fuzzy["transpositions"] = *opts.FuzzyTranspositions
+		}
+		if opts.FuzzyPrefixLength > 0 {
+			fuzzy["prefix_length"] = opts.FuzzyPrefixLength
+		}
+		completion["fuzzy"] = fuzzy
+	}
+	if opts.ContextCategory != "" || opts.ContextGeo != nil || len(opts.Contexts) > 0 {
+		contexts := map[string]interface{}{}
+		for name, values := range opts.Contexts {
+			contexts[name] = values
+		}
+		if opts.ContextCategory != "" {
+			contexts["category"] = []string{opts.ContextCategory}
+		}
+		if opts.ContextGeo != nil {
+			contexts["location"] = []map[string]interface{}{
+				{"lat": opts.ContextGeo.Lat, "lon": opts.ContextGeo.Lon},
+			}
+		}
+		completion["contexts"] = contexts
+	}
+	return map[string]interface{}{
+		"suggest": map[string]interface{}{
+			"text": input,
+			"name_suggestion": map[string]interface{}{
+				"completion": completion,
+			},
+		},
+	}
+}
+
+func (completionStrategy) ParseSuggestions(parsed map[string]interface{}, field string) []string {
+	return suggestionsFromSuggestBlock(parsed, "name_suggestion")
+}
+
+// edgeNgramStrategy matches the edge_ngram-analyzed field directly, relying
+// on the index-time "autocomplete_analyzer" to have produced prefix tokens.
+type edgeNgramStrategy struct{}
+
+func (edgeNgramStrategy) BuildQuery(field, input string, opts SuggestOptions) map[string]interface{} {
+	if opts.MinLength > 0 && len(input) < opts.MinLength {
+		return map[string]interface{}{
+			"query": map[string]interface{}{"match_none": map[string]interface{}{}},
+		}
+	}
+	return map[string]interface{}{
+		"query": map[string]interface{}{
+			"match": map[string]interface{}{
+				field: map[string]interface{}{
+					"query":    input,
+					"analyzer": "standard_analyzer",
+				},
+			},
+		},
+		"_source": []string{field},
+		"size":    10,
+	}
+}
+
+func (edgeNgramStrategy) ParseSuggestions(parsed map[string]interface{}, field string) []string {
+	return suggestionsFromHits(parsed, field)
+}
+
+// searchAsYouTypeStrategy runs a bool_prefix multi_match across the field's
+// shingled sub-fields (Elasticsearch generates these automatically for a
+// "search_as_you_type" mapping).
+type searchAsYouTypeStrategy struct{}
+
+func (searchAsYouTypeStrategy) BuildQuery(field, input string, opts SuggestOptions) map[string]interface{} {
+	if opts.MinLength > 0 && len(input) < opts.MinLength {
+		return map[string]interface{}{
+			"query": map[string]interface{}{"match_none": map[string]interface{}{}},
+		}
+	}
+	return map[string]interface{}{
+		"query": map[string]interface{}{
+			"multi_match": map[string]interface{}{
+				"query": input,
+				"type":  "bool_prefix",
+				"fields": []string{
+					field,
+					field + "._2gram",
+					field + "._3gram",
+				},
+			},
+		},
+		"_source": []string{field},
+		"size":    10,
+	}
+}
+
+func (searchAsYouTypeStrategy) ParseSuggestions(parsed map[string]interface{}, field string) []string {
+	return suggestionsFromHits(parsed, field)
+}
+
+// phraseStrategy uses the phrase suggester to propose did-you-mean style
+// corrections rather than prefix completions.
+type phraseStrategy struct{}
+
+func (phraseStrategy) BuildQuery(field, input string, opts SuggestOptions) map[string]interface{} {
+	phrase := map[string]interface{}{
+		"field": field,
+		"size":  5,
+	}
+	switch {
+	case opts.MaxErrors > 0:
+		phrase["max_errors"] = opts.MaxErrors
+	case opts.Fuzziness != "":
+		phrase["max_errors"] = 2.0
+	}
+	if opts.Confidence > 0 {
+		phrase["confidence"] = opts.Confidence
+	}
+	generatorField := opts.DirectGeneratorField
+	if generatorField == "" {
+		generatorField = field
+	}
+	phrase["direct_generator"] = []map[string]interface{}{
+		{
+			"field":        generatorField,
+			"suggest_mode": "popular",
+		},
+	}
+	return map[string]interface{}{
+		"suggest": map[string]interface{}{
+			"text": input,
+			"phrase_suggestion": map[string]interface{}{
+				"phrase": phrase,
+			},
+		},
+	}
+}
+
+func (phraseStrategy) ParseSuggestions(parsed map[string]interface{}, field string) []string {
+	return suggestionsFromSuggestBlock(parsed, "phrase_suggestion")
+}
+
+// suggestionsFromSuggestBlock extracts `suggest[name][].options[].text`
+// from an already-decoded search response, as returned by the completion
+// and phrase suggesters.
+func suggestionsFromSuggestBlock(parsed map[string]interface{}, name string) []string {
+	suggestions := []string{}
+	suggest, ok := parsed["suggest"].(map[string]interface{})
+	if !ok {
+		return suggestions
+	}
+	entries, ok := suggest[name].([]interface{})
+	if !ok {
+		return suggestions
+	}
+	for _, entry := range entries {
+		options, ok := entry.(map[string]interface{})["options"].([]interface{})
+		if !ok {
+			continue
+		}
+		for _, option := range options {
+			if text, ok := option.(map[string]interface{})["text"].(string); ok {
+				suggestions = append(suggestions, text)
+			}
+		}
+	}
+	return suggestions
+}
+
+// suggestionsFromHits extracts field's value out of `hits.hits[]._source`,
+// as used by the edge_ngram and search_as_you_type strategies, which are
+// plain field queries rather than the `suggest` API.
+func suggestionsFromHits(parsed map[string]interface{}, field string) []string {
+	suggestions := []string{}
+	hits, ok := parsed["hits"].(map[string]interface{})
+	if !ok {
+		return suggestions
+	}
+	entries, ok := hits["hits"].([]interface{})
+	if !ok {
+		return suggestions
+	}
+	for _, entry := range entries {
+		source, ok := entry.(map[string]interface{})["_source"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if text, ok := source[field].(string); ok {
+			suggestions = append(suggestions, text)
+		}
+	}
+	return suggestions
+}
@@ -4,13 +4,13 @@ import (
 	"bytes"
 	"context"
 	"go-es/internal/errors"
-	"net/http"
 )
 
 // UpdateDocument updates a document in an Elasticsearch index
 //
 // It will construct an update request to the Elasticsearch server and return
-// an error if the request fails.
+// an error if the request fails. The caller is responsible for publishing a
+// change-feed event on success (see handler.UpdateDocument).
 //
 // The body should be a JSON byte slice that represents the document to be
 // updated.
@@ -21,12 +21,7 @@ import (
 func (e *elastic) UpdateDocument(ctx context.Context, index string, docID string, body []byte) error {
 	res, err := e.client.Update(index, docID, bytes.NewReader(body), e.client.Update.WithContext(ctx))
 	if err != nil {
-		return errors.ElasticsearchError{
-			StatusCode: http.StatusInternalServerError,
-			Message:    "failed to connect to elastic server",
-			Details:    err,
-			Type:       errors.ConnectionError,
-		}
+		return errors.NewConnectionError(err)
 	}
 	defer res.Body.Close()
 
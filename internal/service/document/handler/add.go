@@ -5,6 +5,7 @@ import (
 	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/gin-gonic/gin"
 	cErr "go-es/internal/errors"
+	"go-es/internal/notify"
 	"go-es/internal/response"
 	"go-es/internal/service/document/elastic"
 	"go-es/internal/service/document/model/request"
@@ -15,8 +16,13 @@ import (
 
 // AddData adds documents to an Elasticsearch index.
 // It extracts the alias from the URI, parses the JSON request body,
-// validates the data, and then calls AddBatchData to add the documents.
-func AddData(e *elasticsearch.Client) func(c *gin.Context) {
+// validates the data, and then calls BulkIndex to add the documents via the
+// Elasticsearch _bulk API, chunked and with per-document retry on transient
+// failures. A partial failure still responds 200 with the indexed/failed
+// counts; per-document failure details are included only when the server
+// was started with response details enabled (see response.Init). On success
+// it publishes a single EventDocumentCreated for the batch to emitter.
+func AddData(e *elasticsearch.Client, emitter notify.Notifier) func(c *gin.Context) {
 	return func(c *gin.Context) {
 		logs := logger.GetLogger(c)
 
@@ -58,9 +64,9 @@ func AddData(e *elasticsearch.Client) func(c *gin.Context) {
 			return
 		}
 
-		// Call AddBatchData to add the documents to Elasticsearch
+		// Call BulkIndex to add the documents to Elasticsearch
 		ec := elastic.New(e)
-		err := ec.AddBatchData(c.Request.Context(), alias, req.Data)
+		result, err := ec.BulkIndex(c.Request.Context(), alias, req.Data, elastic.DefaultBulkOptions())
 		if err != nil {
 			var esErr cErr.ElasticsearchError
 			if errors.As(err, &esErr) {
@@ -83,12 +89,26 @@ func AddData(e *elasticsearch.Client) func(c *gin.Context) {
 			return
 		}
 
-		// Respond with success
-		logs.Info().Msg("data added successfully")
-		c.JSON(http.StatusOK, response.SuccessResponse[any]{
+		if !response.DetailsEnabled() {
+			result.FailedItems = nil
+		}
+
+		logs.Info().Int64("indexed", result.Indexed).Int64("failed", result.Failed).Msg("data added")
+
+		requestID, actor := requestContext(c)
+		emitter.Publish(c.Request.Context(), notify.Event{
+			EventType: notify.EventDocumentCreated,
+			Index:     alias,
+			Ts:        time.Now(),
+			RequestID: requestID,
+			Actor:     actor,
+		})
+
+		c.JSON(http.StatusOK, response.SuccessResponse[elastic.BulkResult]{
 			Ts:      time.Now(),
 			Code:    http.StatusOK,
-			Message: "data added successfully",
+			Message: "data added",
+			Data:    *result,
 		})
 	}
 }
@@ -2,9 +2,9 @@ package handler
 
 import (
 	"errors"
+	"fmt"
 	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/gin-gonic/gin"
-	"github.com/goccy/go-json"
 	cErr "go-es/internal/errors"
 	"go-es/internal/response"
 	"go-es/internal/service/document/elastic"
@@ -50,16 +50,11 @@ func AutoComplete(e *elasticsearch.Client) func(c *gin.Context) {
 			return
 		}
 
-		// Build suggestion query
-		query := buildSuggestQuery(req.Field, req.Input)
-
-		// Convert query to JSON
-		payload, err := json.Marshal(query)
-		if err != nil {
-			logs.Error().Err(err).Msg("failed to marshal auto-complete body")
+		if err := validateSuggestRequest(req); err != nil {
+			logs.Error().Err(err).Msg("invalid suggest request")
 			response.SendErrorResponse(c, response.ErrResponse{
 				Code:    http.StatusBadRequest,
-				Message: "failed to marshal auto-complete body",
+				Message: "invalid suggest request",
 				Details: err.Error(),
 				Type:    cErr.BadRequestError.String(),
 			})
@@ -68,7 +63,18 @@ func AutoComplete(e *elasticsearch.Client) func(c *gin.Context) {
 
 		// Call AutoComplete function
 		ec := elastic.New(e)
-		result, err := ec.AutoComplete(c.Request.Context(), alias, payload)
+		result, err := ec.AutoComplete(c.Request.Context(), alias, req.Field, req.Input, req.Mode, elastic.SuggestOptions{
+			Fuzziness:            req.Fuzziness,
+			FuzzyTranspositions:  req.FuzzyTranspositions,
+			FuzzyPrefixLength:    req.FuzzyPrefixLength,
+			MinLength:            req.MinLength,
+			ContextCategory:      req.ContextCategory,
+			ContextGeo:           req.ContextGeo,
+			Contexts:             req.Contexts,
+			Confidence:           req.Confidence,
+			MaxErrors:            req.MaxErrors,
+			DirectGeneratorField: req.DirectGeneratorField,
+		})
 		if err != nil {
 			var esErr cErr.ElasticsearchError
 			if errors.As(err, &esErr) {
@@ -102,20 +108,30 @@ func AutoComplete(e *elasticsearch.Client) func(c *gin.Context) {
 	}
 }
 
-// buildSuggestQuery constructs the Elasticsearch suggestion query
-//
-// It takes the field and input as arguments and returns a JSON payload
-// that can be used to query the Elasticsearch cluster for autocomplete
-// suggestions.
-func buildSuggestQuery(field, input string) map[string]interface{} {
-	return map[string]interface{}{
-		"suggest": map[string]interface{}{
-			"text": input, // ✅ Fix: Use "text" instead of "prefix"
-			"name_suggestion": map[string]interface{}{
-				"completion": map[string]interface{}{
-					"field": field + ".suggest", // ✅ Fix: Target the "suggest" completion field
-				},
-			},
-		},
+// validateSuggestRequest rejects option combinations that don't apply to
+// req.Mode's suggester: context filters only make sense for the completion
+// suggester, and phrase-suggester tuning only makes sense for req.Mode ==
+// phrase.
+func validateSuggestRequest(req request.SuggestRequest) error {
+	hasContext := req.ContextCategory != "" || req.ContextGeo != nil || len(req.Contexts) > 0
+	hasPhraseTuning := req.Confidence > 0 || req.MaxErrors > 0 || req.DirectGeneratorField != ""
+
+	switch req.Mode {
+	case request.SuggestModePhrase:
+		if hasContext {
+			return fmt.Errorf("context_category/context_geo/contexts are only supported with mode %q", request.SuggestModeCompletion)
+		}
+	case request.SuggestModeCompletion, "":
+		if hasPhraseTuning {
+			return fmt.Errorf("confidence/max_errors/direct_generator_field are only supported with mode %q", request.SuggestModePhrase)
+		}
+	default:
+		if hasContext {
+			return fmt.Errorf("context_category/context_geo/contexts are only supported with mode %q", request.SuggestModeCompletion)
+		}
+		if hasPhraseTuning {
+			return fmt.Errorf("confidence/max_errors/direct_generator_field are only supported with mode %q", request.SuggestModePhrase)
+		}
 	}
+	return nil
 }
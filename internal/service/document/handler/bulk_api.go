@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/gin-gonic/gin"
+	cErr "go-es/internal/errors"
+	"go-es/internal/response"
+	"go-es/internal/service/document/elastic"
+	"go-es/logger"
+	"net/http"
+	"time"
+)
+
+// BulkAPISubmit queues a mixed batch of index/update/delete operations onto
+// the shared BulkAPI background worker and returns without waiting for them
+// to reach Elasticsearch; it blocks only while the worker's inbound queue is
+// full. The request body uses the same
+// NDJSON action/document-line format as BulkIngestData; per-item outcomes
+// are not available synchronously, so the response only reports the
+// cumulative stats of the shared worker at submission time.
+func BulkAPISubmit(e *elasticsearch.Client) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		logs := logger.GetLogger(c)
+
+		alias := c.Param("alias")
+		if alias == "" {
+			logs.Error().Msg("alias name is required in URI")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusBadRequest,
+				Message: "alias name is required in URI",
+				Details: "alias name is required in URI",
+				Type:    cErr.BadRequestError.String(),
+			})
+			return
+		}
+
+		items, err := parseBulkNDJSON(c.Request.Body)
+		if err != nil {
+			logs.Error().Err(err).Msg("invalid NDJSON bulk payload")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusBadRequest,
+				Message: "invalid NDJSON bulk payload",
+				Details: err.Error(),
+				Type:    cErr.BadRequestError.String(),
+			})
+			return
+		}
+
+		if len(items) == 0 {
+			logs.Error().Msg("bulk payload is empty")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusBadRequest,
+				Message: "bulk payload is empty",
+				Details: "bulk payload is empty",
+				Type:    cErr.BadRequestError.String(),
+			})
+			return
+		}
+
+		api := elastic.BulkAPIFor(e)
+		for _, item := range items {
+			api.Submit(toBulkRequest(alias, item))
+		}
+
+		logs.Info().Int("queued", len(items)).Msg("bulk items queued for async ingest")
+		c.JSON(http.StatusAccepted, response.SuccessResponse[elastic.BulkAPIStats]{
+			Ts:      time.Now(),
+			Code:    http.StatusAccepted,
+			Message: "bulk items queued",
+			Data:    api.Stats(),
+		})
+	}
+}
+
+// toBulkRequest converts a parsed BulkIngestItem into the typed BulkRequest
+// BulkAPI expects.
+func toBulkRequest(alias string, item elastic.BulkIngestItem) elastic.BulkRequest {
+	switch item.Action {
+	case "update":
+		return elastic.BulkUpdateRequest{Index: alias, ID: item.ID, Doc: item.Doc}
+	case "delete":
+		return elastic.BulkDeleteRequest{Index: alias, ID: item.ID}
+	default:
+		return elastic.BulkIndexRequest{Index: alias, ID: item.ID, Doc: item.Doc}
+	}
+}
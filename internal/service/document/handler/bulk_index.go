@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"errors"
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/gin-gonic/gin"
+	cErr "go-es/internal/errors"
+	"go-es/internal/response"
+	"go-es/internal/service/document/elastic"
+	"go-es/logger"
+	"net/http"
+	"time"
+)
+
+// BulkIndex bulk-indexes a flat JSON array of documents into the index
+// named by the alias in the URI, through an esutil.BulkIndexer. Each
+// document may carry an "id" field to control its document ID;
+// Elasticsearch assigns one otherwise. Unlike BulkIngestData, every item is
+// an "index" operation — there's no per-item action/update/delete support,
+// which keeps the request body a plain array instead of NDJSON pairs.
+func BulkIndex(e *elasticsearch.Client) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		logs := logger.GetLogger(c)
+
+		alias := c.Param("alias")
+		if alias == "" {
+			logs.Error().Msg("alias name is required in URI")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusBadRequest,
+				Message: "alias name is required in URI",
+				Details: "alias name is required in URI",
+				Type:    cErr.BadRequestError.String(),
+			})
+			return
+		}
+
+		var docs []map[string]interface{}
+		if err := c.ShouldBindJSON(&docs); err != nil {
+			logs.Error().Err(err).Msg("invalid request payload")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusBadRequest,
+				Message: "invalid JSON array payload",
+				Details: err.Error(),
+				Type:    cErr.BadRequestError.String(),
+			})
+			return
+		}
+
+		if len(docs) == 0 {
+			logs.Error().Msg("bulk payload is empty")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusBadRequest,
+				Message: "bulk payload is empty",
+				Details: "bulk payload is empty",
+				Type:    cErr.BadRequestError.String(),
+			})
+			return
+		}
+
+		ec := elastic.New(e)
+		result, err := ec.BulkIndex(c.Request.Context(), alias, docs, elastic.DefaultBulkOptions())
+		if err != nil {
+			var esErr cErr.ElasticsearchError
+			if errors.As(err, &esErr) {
+				logs.Error().Err(esErr.Details).Msg(esErr.Message)
+				response.SendErrorResponse(c, response.ErrResponse{
+					Code:    esErr.StatusCode,
+					Message: esErr.Message,
+					Details: esErr.Details.Error(),
+					Type:    esErr.Type.String(),
+				})
+				return
+			}
+			logs.Error().Err(err).Msg("bulk index failed")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusInternalServerError,
+				Message: "bulk index failed",
+				Details: err.Error(),
+				Type:    cErr.ServerError.String(),
+			})
+			return
+		}
+
+		logs.Info().Int64("indexed", result.Indexed).Int64("failed", result.Failed).Msg("bulk index completed")
+		c.JSON(http.StatusOK, response.SuccessResponse[elastic.BulkResult]{
+			Ts:      time.Now(),
+			Code:    http.StatusOK,
+			Message: "bulk index completed",
+			Data:    *result,
+		})
+	}
+}
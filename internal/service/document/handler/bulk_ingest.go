@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/gin-gonic/gin"
+	"github.com/goccy/go-json"
+	cErr "go-es/internal/errors"
+	"go-es/internal/response"
+	"go-es/internal/service/document/elastic"
+	"go-es/logger"
+	"io"
+	"net/http"
+	"time"
+)
+
+// BulkIngestData ingests a mixed batch of index/create/update/delete
+// operations for the index named by the alias in the URI. The request body
+// is NDJSON in the same shape as Elasticsearch's own `_bulk` API: an action
+// meta-data line (`{"index":{"_id":"..."}}`, `"create"`, `"update"`, or
+// `"delete"`) followed by the document line, except for "delete" which has
+// no document line. Per-document failures are reported in the response
+// summary instead of failing the whole request.
+func BulkIngestData(e *elasticsearch.Client) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		logs := logger.GetLogger(c)
+
+		alias := c.Param("alias")
+		if alias == "" {
+			logs.Error().Msg("alias name is required in URI")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusBadRequest,
+				Message: "alias name is required in URI",
+				Details: "alias name is required in URI",
+				Type:    cErr.BadRequestError.String(),
+			})
+			return
+		}
+
+		items, err := parseBulkNDJSON(c.Request.Body)
+		if err != nil {
+			logs.Error().Err(err).Msg("invalid NDJSON bulk payload")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusBadRequest,
+				Message: "invalid NDJSON bulk payload",
+				Details: err.Error(),
+				Type:    cErr.BadRequestError.String(),
+			})
+			return
+		}
+
+		if len(items) == 0 {
+			logs.Error().Msg("bulk payload is empty")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusBadRequest,
+				Message: "bulk payload is empty",
+				Details: "bulk payload is empty",
+				Type:    cErr.BadRequestError.String(),
+			})
+			return
+		}
+
+		ec := elastic.New(e)
+		summary, err := ec.BulkIngest(c.Request.Context(), alias, items, elastic.DefaultBulkIngestOptions())
+		if err != nil {
+			var esErr cErr.ElasticsearchError
+			if errors.As(err, &esErr) {
+				logs.Error().Err(esErr.Details).Msg(esErr.Message)
+				response.SendErrorResponse(c, response.ErrResponse{
+					Code:    esErr.StatusCode,
+					Message: esErr.Message,
+					Details: esErr.Details.Error(),
+					Type:    esErr.Type.String(),
+				})
+				return
+			}
+			logs.Error().Err(err).Msg("bulk ingest failed")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusInternalServerError,
+				Message: "bulk ingest failed",
+				Details: err.Error(),
+				Type:    cErr.ServerError.String(),
+			})
+			return
+		}
+
+		logs.Info().Int64("indexed", summary.Indexed).Int64("failed", summary.Failed).Msg("bulk ingest completed")
+		c.JSON(http.StatusOK, response.SuccessResponse[elastic.BulkIngestSummary]{
+			Ts:      time.Now(),
+			Code:    http.StatusOK,
+			Message: "bulk ingest completed",
+			Data:    *summary,
+		})
+	}
+}
+
+// parseBulkNDJSON reads action meta-data/document line pairs off r into a
+// slice of BulkIngestItem, matching the wire format of Elasticsearch's own
+// `_bulk` API.
+func parseBulkNDJSON(r io.Reader) ([]elastic.BulkIngestItem, error) {
+	var items []elastic.BulkIngestItem
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+
+	for scanner.Scan() {
+		metaLine := bytes.TrimSpace(scanner.Bytes())
+		if len(metaLine) == 0 {
+			continue
+		}
+
+		var meta map[string]struct {
+			ID string `json:"_id"`
+		}
+		if err := json.Unmarshal(metaLine, &meta); err != nil {
+			return nil, err
+		}
+
+		var action string
+		var id string
+		for k, v := range meta {
+			action, id = k, v.ID
+		}
+
+		item := elastic.BulkIngestItem{Action: action, ID: id}
+
+		if action != "delete" {
+			if !scanner.Scan() {
+				return nil, errors.New("bulk payload ended before a document line")
+			}
+			docLine := bytes.TrimSpace(scanner.Bytes())
+			if err := json.Unmarshal(docLine, &item.Doc); err != nil {
+				return nil, err
+			}
+		}
+
+		items = append(items, item)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
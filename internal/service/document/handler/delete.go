@@ -5,6 +5,7 @@ import (
 	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/gin-gonic/gin"
 	cErr "go-es/internal/errors"
+	"go-es/internal/notify"
 	"go-es/internal/response"
 	"go-es/internal/service/document/elastic"
 	"go-es/logger"
@@ -15,8 +16,9 @@ import (
 // DeleteDocument handles deleting a document in an Elasticsearch index.
 //
 // This function will validate the inputs (alias and document ID), resolve the
-// alias to the actual index name, and then delete the document.
-func DeleteDocument(e *elasticsearch.Client) func(c *gin.Context) {
+// alias to the actual index name, and then delete the document. On success
+// it publishes an EventDocumentDeleted to emitter.
+func DeleteDocument(e *elasticsearch.Client, emitter notify.Notifier) func(c *gin.Context) {
 	return func(c *gin.Context) {
 		logs := logger.GetLogger(c)
 
@@ -71,6 +73,17 @@ func DeleteDocument(e *elasticsearch.Client) func(c *gin.Context) {
 		}
 
 		logs.Info().Str("doc_id", docID).Msg("document deleted")
+
+		requestID, actor := requestContext(c)
+		emitter.Publish(c.Request.Context(), notify.Event{
+			EventType:  notify.EventDocumentDeleted,
+			Index:      alias,
+			DocumentID: docID,
+			Ts:         time.Now(),
+			RequestID:  requestID,
+			Actor:      actor,
+		})
+
 		// Return a success response
 		c.JSON(http.StatusOK, response.SuccessResponse[any]{
 			Ts:      time.Now(),
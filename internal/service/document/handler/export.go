@@ -11,8 +11,10 @@ import (
 	"go-es/internal/service/document/elastic"
 	"go-es/internal/service/document/model/request"
 	"go-es/logger"
+	middlewares "go-es/server/middleware"
 	"net/http"
 	"strings"
+	"time"
 )
 
 // ExportDocuments returns documents in an Elasticsearch index
@@ -39,6 +41,11 @@ func ExportDocuments(e *elasticsearch.Client) func(c *gin.Context) {
 			return
 		}
 
+		// Restrict access to tenants/roles listed in the caller's "indices" claim
+		if !middlewares.AuthorizeIndex(c, alias) {
+			return
+		}
+
 		// Parse the JSON body for filters (optional)
 		var req request.ExportDocumentsRequest
 		if c.Request.ContentLength > 0 { // Only parse body if it exists
@@ -58,8 +65,16 @@ func ExportDocuments(e *elasticsearch.Client) func(c *gin.Context) {
 		bulk := c.DefaultQuery("bulk", "false") // e.g., "true" or "false"
 		isBulk := bulk == "true"
 
-		// Call ExportDocuments function
 		ec := elastic.New(e)
+
+		// PIT mode streams directly to the response instead of buffering
+		// every document in memory.
+		if req.Mode == request.ExportModePIT {
+			streamExportPIT(c, ec, alias, req.Query, req.Format, req.Columns)
+			return
+		}
+
+		// Call ExportDocuments function
 		documents, err := ec.ExportDocuments(c.Request.Context(), alias, req.Query)
 		if err != nil {
 			var esErr cErr.ElasticsearchError
@@ -111,3 +126,59 @@ func ExportDocuments(e *elasticsearch.Client) func(c *gin.Context) {
 
 	}
 }
+
+// streamExportPIT drives elastic.ExportDocumentsEncoded and flushes each
+// document to the response as soon as it arrives, so a client can download
+// a full export without the server buffering it in memory. gin's Writer
+// omits Content-Length once bytes are flushed before the handler returns,
+// so the response goes out chunked.
+func streamExportPIT(c *gin.Context, ec elastic.Elastic, alias string, query map[string]interface{}, format request.ExportFormat, columns []string) {
+	logs := logger.GetLogger(c)
+
+	var enc elastic.Encoder
+	switch format {
+	case request.ExportFormatJSON:
+		c.Header("Content-Type", "application/json")
+		c.Header("Content-Disposition", "attachment; filename=export.json")
+		enc = &elastic.JSONArrayEncoder{}
+	case request.ExportFormatCSV:
+		c.Header("Content-Type", "text/csv")
+		c.Header("Content-Disposition", "attachment; filename=export.csv")
+		enc = &elastic.CSVEncoder{Columns: columns}
+	default:
+		c.Header("Content-Type", "application/x-ndjson")
+		c.Header("Content-Disposition", "attachment; filename=export.ndjson")
+		enc = elastic.NDJSONEncoder{}
+	}
+	c.Status(http.StatusOK)
+
+	flusher := flushingWriter{c.Writer}
+	count, err := ec.ExportDocumentsEncoded(c.Request.Context(), alias, query, elastic.DefaultExportOptions(), enc, flusher)
+	if err != nil {
+		logs.Error().Err(err).Int64("exported", count).Msg("export stream failed")
+		return
+	}
+	logs.Info().Int64("exported", count).Msg("export stream completed")
+}
+
+// flushingWriter flushes the gin response writer after every write so each
+// document reaches the client as soon as it's encoded.
+type flushingWriter struct {
+	w gin.ResponseWriter
+}
+
+func (f flushingWriter) Write(p []byte) (int, error) {
+	resetWriteDeadline(f.w)
+	n, err := f.w.Write(p)
+	f.w.Flush()
+	return n, err
+}
+
+// resetWriteDeadline pushes w's write deadline out from http.Server's
+// WriteTimeout on every flush, so a stream that outlives that timeout isn't
+// killed mid-transfer as long as it keeps making progress.
+func resetWriteDeadline(w gin.ResponseWriter) {
+	if err := http.NewResponseController(w).SetWriteDeadline(time.Now().Add(streamWriteDeadlineExtension)); err != nil {
+		return
+	}
+}
@@ -1,31 +1,34 @@
 package handler
 
 import (
-	"bufio"
-	"bytes"
 	"errors"
 	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/gin-gonic/gin"
-	"github.com/goccy/go-json"
 	cErr "go-es/internal/errors"
+	"go-es/internal/notify"
 	"go-es/internal/response"
 	"go-es/internal/service/document/elastic"
 	"go-es/internal/service/document/model/request"
 	"go-es/logger"
 	"io"
-	"mime/multipart"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 )
 
 // ImportDocuments handles importing documents into Elasticsearch.
 //
-// This handler function parses the incoming request to determine the source of
-// the documents to be imported, either from an uploaded file or a JSON string.
-// It supports bulk import if specified, and uses the ImportDocuments method
-// from the elastic package to insert the documents into the specified index.
-func ImportDocuments(e *elasticsearch.Client) func(*gin.Context) {
+// The uploaded file (or inline JSON content) is streamed directly into an
+// esutil.BulkIndexer without ever being read fully into memory first: a
+// scanner goroutine parses it incrementally according to req.Format (one
+// JSON document per line, Elasticsearch bulk format, or a single top-level
+// JSON array) and hands documents to the indexer over a bounded channel, so
+// a multi-GB import can't OOM the server. It returns a structured summary of
+// how many documents were indexed and which ones failed. If at least one
+// document was indexed, a single EventDocumentCreated for the whole import is
+// published to emitter.
+func ImportDocuments(e *elasticsearch.Client, emitter notify.Notifier) func(*gin.Context) {
 	return func(c *gin.Context) {
 		logs := logger.GetLogger(c)
 
@@ -48,15 +51,35 @@ func ImportDocuments(e *elasticsearch.Client) func(*gin.Context) {
 			req.Bulk, _ = strconv.ParseBool(bulkParam)
 		}
 
-		var documents []map[string]interface{}
-		var err error
+		format := req.Format
+		if format == "" {
+			format = request.ImportFormatJSON
+			if req.Bulk {
+				format = request.ImportFormatBulk
+			}
+		}
 
-		// Determine the source of input, file or JSON content
-		if req.File != nil {
-			documents, err = parseFile(req.File, req.Bulk)
-		} else if req.JSON != "" {
-			documents, err = parseJSON(req.JSON, req.Bulk)
-		} else {
+		// Determine the source of input, file or JSON content, and stream
+		// it directly rather than reading it into memory.
+		var r io.Reader
+		switch {
+		case req.File != nil:
+			file, err := req.File.Open()
+			if err != nil {
+				logs.Error().Err(err).Msg("failed to open import file")
+				response.SendErrorResponse(c, response.ErrResponse{
+					Code:    http.StatusBadRequest,
+					Message: "failed to open import file",
+					Details: err.Error(),
+					Type:    cErr.BadRequestError.String(),
+				})
+				return
+			}
+			defer file.Close()
+			r = file
+		case req.JSON != "":
+			r = strings.NewReader(req.JSON)
+		default:
 			logs.Error().Msg("no file or JSON content provided")
 			response.SendErrorResponse(c, response.ErrResponse{
 				Code:    http.StatusBadRequest,
@@ -67,21 +90,9 @@ func ImportDocuments(e *elasticsearch.Client) func(*gin.Context) {
 			return
 		}
 
-		// Handle error if parsing inputs fails
-		if err != nil {
-			logs.Error().Err(err).Msg("failed to parse inputs")
-			response.SendErrorResponse(c, response.ErrResponse{
-				Code:    http.StatusBadRequest,
-				Message: "failed to parse input",
-				Details: err.Error(),
-				Type:    cErr.BadRequestError.String(),
-			})
-			return
-		}
-
-		// Attempt to import documents into Elasticsearch
 		ec := elastic.New(e)
-		if err := ec.ImportDocuments(c.Request.Context(), req.Index, documents); err != nil {
+		summary, err := ec.ImportDocumentsStream(c.Request.Context(), req.Index, r, format, elastic.DefaultImportOptions())
+		if err != nil {
 			var esErr cErr.ElasticsearchError
 			if errors.As(err, &esErr) {
 				logs.Error().Err(esErr.Details).Msg(esErr.Message)
@@ -103,111 +114,24 @@ func ImportDocuments(e *elasticsearch.Client) func(*gin.Context) {
 			return
 		}
 
-		// Success response
-		logs.Info().Msg("documents imported successfully")
-		c.JSON(http.StatusOK, response.SuccessResponse[any]{
-			Ts:      time.Now(),
-			Code:    http.StatusOK,
-			Message: "documents imported successfully",
-		})
-	}
-}
-
-// parseFile reads and parses the uploaded file.
-//
-// The uploaded file is read and parsed into individual documents based on the
-// bulk flag. If the bulk flag is set to true, the file is expected to be in
-// Elasticsearch bulk format. Otherwise, each line of the file is expected to
-// contain a single JSON document.
-func parseFile(fileHeader *multipart.FileHeader, isBulk bool) ([]map[string]interface{}, error) {
-	file, err := fileHeader.Open()
-	if err != nil {
-		return nil, errors.New("failed to open import file")
-	}
-	defer file.Close()
-
-	// Read the file content
-	fileContent, err := io.ReadAll(file)
-	if err != nil {
-		return nil, errors.New("failed to read file content")
-	}
+		logs.Info().Int64("indexed", summary.Indexed).Int64("failed", summary.Failed).Msg("documents imported")
 
-	// Parse based on the bulk flag
-	if isBulk {
-		return parseBulkJSON(fileContent)
-	}
-	return parsePureJSON(fileContent)
-}
-
-// parseJSON parses the JSON content provided in the request body.
-//
-// It accepts a JSON string and a boolean indicating whether the content
-// is in bulk format. If the bulk flag is true, the function delegates
-// the parsing to parseBulkJSON, which handles Elasticsearch bulk format.
-// Otherwise, it uses parsePureJSON to parse the JSON as a pure array.
-func parseJSON(jsonStr string, isBulk bool) ([]map[string]interface{}, error) {
-	// Convert the JSON string to a byte slice
-	jsonBytes := []byte(jsonStr)
-
-	// Delegate parsing based on the bulk flag
-	if isBulk {
-		return parseBulkJSON(jsonBytes)
-	}
-	return parsePureJSON(jsonBytes)
-}
-
-// parseBulkJSON parses JSON in Elasticsearch bulk format.
-//
-// This function takes a byte slice of data in bulk format, where each
-// document is preceded by a metadata line. It scans through the data,
-// verifying the presence of an "index" field in the metadata and
-// unmarshals both metadata and document lines into a slice of maps.
-// It returns the documents or an error if parsing fails.
-func parseBulkJSON(data []byte) ([]map[string]interface{}, error) {
-	var documents []map[string]interface{}
-	scanner := bufio.NewScanner(bytes.NewReader(data))
-
-	for scanner.Scan() {
-		// Read the metadata line
-		metaLine := scanner.Bytes()
-		var meta map[string]interface{}
-		if err := json.Unmarshal(metaLine, &meta); err != nil {
-			return nil, errors.New("invalid metadata line in bulk JSON")
-		}
-
-		// Ensure the metadata line contains an "index" field
-		if _, exists := meta["index"]; !exists {
-			return nil, errors.New("metadata line must contain an 'index' field")
-		}
-
-		// Read the document line
-		if !scanner.Scan() {
-			return nil, errors.New("missing document line in bulk JSON")
-		}
-		docLine := scanner.Bytes()
-		var doc map[string]interface{}
-		if err := json.Unmarshal(docLine, &doc); err != nil {
-			return nil, errors.New("invalid document line in bulk JSON")
+		if summary.Indexed > 0 {
+			requestID, actor := requestContext(c)
+			emitter.Publish(c.Request.Context(), notify.Event{
+				EventType: notify.EventDocumentCreated,
+				Index:     req.Index,
+				Ts:        time.Now(),
+				RequestID: requestID,
+				Actor:     actor,
+			})
 		}
 
-		// Append the document to the list
-		documents = append(documents, doc)
-	}
-
-	if err := scanner.Err(); err != nil {
-		return nil, errors.New("failed to read bulk JSON")
-	}
-
-	return documents, nil
-}
-
-// parsePureJSON parses JSON in pure array format.
-// It takes a byte slice and unmarshals it into a slice of maps.
-// It returns the documents or an error if parsing fails.
-func parsePureJSON(data []byte) ([]map[string]interface{}, error) {
-	var documents []map[string]interface{}
-	if err := json.Unmarshal(data, &documents); err != nil {
-		return nil, errors.New("invalid JSON format")
+		c.JSON(http.StatusOK, response.SuccessResponse[elastic.ImportSummary]{
+			Ts:      time.Now(),
+			Code:    http.StatusOK,
+			Message: "documents imported",
+			Data:    *summary,
+		})
 	}
-	return documents, nil
 }
@@ -4,10 +4,13 @@ import (
 	"errors"
 	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/gin-gonic/gin"
+	"github.com/goccy/go-json"
+	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	cErr "go-es/internal/errors"
 	"go-es/internal/response"
 	"go-es/internal/service/document/elastic"
+	"go-es/internal/service/document/model/request"
 	docResp "go-es/internal/service/document/model/response"
 	"net/http"
 	"strconv"
@@ -25,6 +28,11 @@ import (
 // specifies the number of documents per page. Both parameters are optional. If
 // not provided, the endpoint will return the first page of documents with a size
 // of 10.
+//
+// Large indices can instead be streamed in full as NDJSON by setting
+// `?mode=scroll` or `?mode=search_after`, which bypass `page`/`size` and
+// stream results back as they arrive instead of buffering the whole result
+// set in memory.
 func ListAllDocuments(e *elasticsearch.Client) func(ctx *gin.Context) {
 	return func(c *gin.Context) {
 		logs := log.With().Str("handler", "ListAllDocuments").Logger()
@@ -42,6 +50,18 @@ func ListAllDocuments(e *elasticsearch.Client) func(ctx *gin.Context) {
 			return
 		}
 
+		ec := elastic.New(e)
+
+		mode := request.ListMode(c.DefaultQuery("mode", string(request.ListModePage)))
+		switch mode {
+		case request.ListModeScroll:
+			streamListScroll(c, ec, alias)
+			return
+		case request.ListModeSearchAfter:
+			streamListSearchAfter(c, ec, alias)
+			return
+		}
+
 		// Parse pagination parameters
 		page := c.DefaultQuery("page", "1")
 		size := c.DefaultQuery("size", "10")
@@ -76,7 +96,6 @@ func ListAllDocuments(e *elasticsearch.Client) func(ctx *gin.Context) {
 			"size": sizeInt,
 		}
 
-		ec := elastic.New(e)
 		searchResult, err := ec.ListAllDocuments(c.Request.Context(), alias, query)
 		if err != nil {
 			var esErr cErr.ElasticsearchError
@@ -132,3 +151,96 @@ func transformSearchResult(searchResult map[string]interface{}) docResp.ListDocu
 		Documents: documents,
 	}
 }
+
+// streamListScroll drives elastic.ScrollDocuments and flushes each document
+// to the response as NDJSON as soon as its batch arrives, so a client can
+// list a full index without the server buffering it in memory.
+func streamListScroll(c *gin.Context, ec elastic.Elastic, alias string) {
+	logs := log.With().Str("handler", "ListAllDocuments").Str("mode", string(request.ListModeScroll)).Logger()
+
+	c.Header("Content-Type", "application/x-ndjson")
+	c.Status(http.StatusOK)
+
+	batches, errs := ec.ScrollDocuments(c.Request.Context(), alias, nil, elastic.DefaultScrollBatchSize, elastic.DefaultScrollKeepAlive)
+
+	for batches != nil || errs != nil {
+		select {
+		case batch, ok := <-batches:
+			if !ok {
+				batches = nil
+				continue
+			}
+			for _, doc := range batch.Documents {
+				writeNDJSONLine(c, logs, doc.Data)
+			}
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			if err != nil {
+				logs.Error().Err(err).Msg("scroll stream failed")
+			}
+		}
+	}
+}
+
+// streamListSearchAfter drives elastic.SearchAfter and flushes each document
+// to the response as NDJSON as soon as it arrives.
+func streamListSearchAfter(c *gin.Context, ec elastic.Elastic, alias string) {
+	logs := log.With().Str("handler", "ListAllDocuments").Str("mode", string(request.ListModeSearchAfter)).Logger()
+
+	c.Header("Content-Type", "application/x-ndjson")
+	c.Status(http.StatusOK)
+
+	docs, errs := ec.SearchAfter(c.Request.Context(), alias, nil, elastic.DefaultExportOptions())
+
+	for docs != nil || errs != nil {
+		select {
+		case doc, ok := <-docs:
+			if !ok {
+				docs = nil
+				continue
+			}
+			writeNDJSONLine(c, logs, doc.Data)
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			if err != nil {
+				logs.Error().Err(err).Msg("search_after stream failed")
+			}
+		}
+	}
+}
+
+// writeNDJSONLine marshals data and writes it as a single flushed NDJSON
+// line on c's response writer.
+func writeNDJSONLine(c *gin.Context, logs zerolog.Logger, data map[string]interface{}) {
+	line, err := json.Marshal(data)
+	if err != nil {
+		logs.Error().Err(err).Msg("failed to marshal listed document")
+		return
+	}
+	resetStreamWriteDeadline(c)
+	c.Writer.Write(line)
+	c.Writer.Write([]byte("\n"))
+	c.Writer.Flush()
+}
+
+// streamWriteDeadlineExtension is how far past "now" each flush pushes the
+// connection's write deadline. It must comfortably exceed the expected gap
+// between flushes (one document/batch), not the whole stream's duration.
+const streamWriteDeadlineExtension = 60 * time.Second
+
+// resetStreamWriteDeadline pushes the connection's write deadline out from
+// http.Server.WriteTimeout on every flush, so a stream that outlives that
+// timeout isn't killed mid-transfer as long as it keeps making progress.
+func resetStreamWriteDeadline(c *gin.Context) {
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Now().Add(streamWriteDeadlineExtension)); err != nil {
+		// Writer doesn't support deadlines (e.g. in tests); WriteTimeout's
+		// original deadline still applies, so just proceed without one.
+		return
+	}
+}
@@ -0,0 +1,21 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	middlewares "go-es/server/middleware"
+)
+
+// requestContext pulls the request ID and authenticated subject (if any) off
+// c, for stamping onto a notify.Event so the change feed can be correlated
+// back to the request that produced it.
+func requestContext(c *gin.Context) (requestID string, actor string) {
+	requestID = c.GetString(middlewares.RequestIDKey)
+
+	if claims := middlewares.Claims(c); claims != nil {
+		if sub, ok := claims["sub"].(string); ok {
+			actor = sub
+		}
+	}
+
+	return requestID, actor
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"errors"
+	"fmt"
 	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/gin-gonic/gin"
 	"github.com/goccy/go-json"
@@ -50,7 +51,17 @@ func Search(e *elasticsearch.Client) func(c *gin.Context) {
 		}
 
 		// Build the query
-		query := buildQuery(req)
+		query, err := buildQuery(req)
+		if err != nil {
+			logs.Error().Err(err).Msg("invalid query clause")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusBadRequest,
+				Message: "invalid query clause",
+				Details: err.Error(),
+				Type:    cErr.BadRequestError.String(),
+			})
+			return
+		}
 		if query == nil {
 			logs.Error().Msg("no valid query provided")
 			response.SendErrorResponse(c, response.ErrResponse{
@@ -81,6 +92,33 @@ func Search(e *elasticsearch.Client) func(c *gin.Context) {
 			searchBody["min_score"] = req.MinScore
 		}
 
+		// A sort is required for search_after to page deterministically past
+		// the from+size window; when a cursor is supplied, from is dropped
+		// since Elasticsearch rejects the two together.
+		if len(req.Sort) > 0 {
+			searchBody["sort"] = req.Sort
+		}
+		if len(req.SearchAfter) > 0 {
+			searchBody["search_after"] = req.SearchAfter
+			delete(searchBody, "from")
+		}
+
+		// Add aggregations if requested
+		if len(req.Aggregations) > 0 {
+			aggs, err := buildAggregations(req.Aggregations)
+			if err != nil {
+				logs.Error().Err(err).Msg("invalid aggregation spec")
+				response.SendErrorResponse(c, response.ErrResponse{
+					Code:    http.StatusBadRequest,
+					Message: "invalid aggregation spec",
+					Details: err.Error(),
+					Type:    cErr.BadRequestError.String(),
+				})
+				return
+			}
+			searchBody["aggs"] = aggs
+		}
+
 		// Perform the search
 		bodyBytes, err := json.Marshal(searchBody)
 		if err != nil {
@@ -129,8 +167,12 @@ func Search(e *elasticsearch.Client) func(c *gin.Context) {
 	}
 }
 
-// buildQuery constructs the Elasticsearch query based on the search request
-func buildQuery(req request.SearchRequest) map[string]interface{} {
+// buildQuery constructs the Elasticsearch query based on the search request.
+// It returns an error if req.Must/Should/MustNot/Filter contains a Clause
+// with zero or more than one of its tagged-union fields set, so the caller
+// can surface a BadRequestError instead of letting Elasticsearch reject the
+// malformed DSL.
+func buildQuery(req request.SearchRequest) (map[string]interface{}, error) {
 	var query map[string]interface{}
 
 	if req.MatchAll {
@@ -178,7 +220,47 @@ func buildQuery(req request.SearchRequest) map[string]interface{} {
 		}
 	}
 
-	return query
+	// Layer the structured Must/Should/MustNot/Filter DSL onto whatever
+	// query was built above, nesting it as an extra "must" clause so both
+	// forms can be combined in one request.
+	must, err := buildClauses(req.Must)
+	if err != nil {
+		return nil, err
+	}
+	should, err := buildClauses(req.Should)
+	if err != nil {
+		return nil, err
+	}
+	mustNot, err := buildClauses(req.MustNot)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := buildClauses(req.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(must) > 0 || len(should) > 0 || len(mustNot) > 0 || len(filter) > 0 {
+		if query != nil {
+			must = append(must, query)
+		}
+		boolQuery := map[string]interface{}{}
+		if len(must) > 0 {
+			boolQuery["must"] = must
+		}
+		if len(should) > 0 {
+			boolQuery["should"] = should
+		}
+		if len(mustNot) > 0 {
+			boolQuery["must_not"] = mustNot
+		}
+		if len(filter) > 0 {
+			boolQuery["filter"] = filter
+		}
+		query = map[string]interface{}{"bool": boolQuery}
+	}
+
+	return query, nil
 }
 
 // buildFilters constructs the filter clauses for the Elasticsearch query
@@ -193,3 +275,123 @@ func buildFilters(filters map[string]interface{}) []map[string]interface{} {
 	}
 	return filterList
 }
+
+// buildClauses renders a slice of request.Clause into their Elasticsearch
+// DSL form, erroring on the first malformed clause.
+func buildClauses(clauses []request.Clause) ([]map[string]interface{}, error) {
+	if len(clauses) == 0 {
+		return nil, nil
+	}
+	rendered := make([]map[string]interface{}, 0, len(clauses))
+	for i, c := range clauses {
+		src, err := buildClause(c)
+		if err != nil {
+			return nil, fmt.Errorf("clause %d: %w", i, err)
+		}
+		rendered = append(rendered, src)
+	}
+	return rendered, nil
+}
+
+// buildClause renders a single tagged-union request.Clause into its
+// Elasticsearch DSL form. Exactly one of the clause's fields must be set.
+func buildClause(c request.Clause) (map[string]interface{}, error) {
+	var result map[string]interface{}
+	set := 0
+
+	if len(c.Term) > 0 {
+		set++
+		result = map[string]interface{}{"term": c.Term}
+	}
+	if len(c.Terms) > 0 {
+		set++
+		result = map[string]interface{}{"terms": c.Terms}
+	}
+	if len(c.Range) > 0 {
+		set++
+		rng := make(map[string]interface{}, len(c.Range))
+		for field, r := range c.Range {
+			bounds := make(map[string]interface{}, 4)
+			if r.Gte != nil {
+				bounds["gte"] = r.Gte
+			}
+			if r.Lte != nil {
+				bounds["lte"] = r.Lte
+			}
+			if r.Gt != nil {
+				bounds["gt"] = r.Gt
+			}
+			if r.Lt != nil {
+				bounds["lt"] = r.Lt
+			}
+			rng[field] = bounds
+		}
+		result = map[string]interface{}{"range": rng}
+	}
+	if len(c.Prefix) > 0 {
+		set++
+		result = map[string]interface{}{"prefix": c.Prefix}
+	}
+	if len(c.Wildcard) > 0 {
+		set++
+		result = map[string]interface{}{"wildcard": c.Wildcard}
+	}
+	if c.Exists != "" {
+		set++
+		result = map[string]interface{}{"exists": map[string]interface{}{"field": c.Exists}}
+	}
+	if c.GeoDistance != nil {
+		set++
+		result = map[string]interface{}{
+			"geo_distance": map[string]interface{}{
+				"distance":          c.GeoDistance.Distance,
+				c.GeoDistance.Field: map[string]interface{}{"lat": c.GeoDistance.Lat, "lon": c.GeoDistance.Lon},
+			},
+		}
+	}
+	if c.Nested != nil {
+		set++
+		if c.Nested.Query == nil {
+			return nil, fmt.Errorf("nested clause must set query")
+		}
+		inner, err := buildClause(*c.Nested.Query)
+		if err != nil {
+			return nil, fmt.Errorf("nested query: %w", err)
+		}
+		result = map[string]interface{}{"nested": map[string]interface{}{"path": c.Nested.Path, "query": inner}}
+	}
+
+	if set != 1 {
+		return nil, fmt.Errorf("clause must set exactly one of term, terms, range, prefix, wildcard, exists, geo_distance, nested (got %d)", set)
+	}
+	return result, nil
+}
+
+// buildAggregations renders req.Aggregations into the Elasticsearch "aggs"
+// section, erroring on an unknown AggType.
+func buildAggregations(specs map[string]request.AggSpec) (map[string]interface{}, error) {
+	aggs := make(map[string]interface{}, len(specs))
+	for name, spec := range specs {
+		switch spec.Type {
+		case request.AggTypeTerms:
+			terms := map[string]interface{}{"field": spec.Field}
+			if spec.Size > 0 {
+				terms["size"] = spec.Size
+			}
+			aggs[name] = map[string]interface{}{"terms": terms}
+		case request.AggTypeDateHistogram:
+			histogram := map[string]interface{}{"field": spec.Field, "calendar_interval": spec.CalendarInterval}
+			if spec.Format != "" {
+				histogram["format"] = spec.Format
+			}
+			aggs[name] = map[string]interface{}{"date_histogram": histogram}
+		case request.AggTypeStats:
+			aggs[name] = map[string]interface{}{"stats": map[string]interface{}{"field": spec.Field}}
+		case request.AggTypeCardinality:
+			aggs[name] = map[string]interface{}{"cardinality": map[string]interface{}{"field": spec.Field}}
+		default:
+			return nil, fmt.Errorf("aggregation %q: unknown type %q", name, spec.Type)
+		}
+	}
+	return aggs, nil
+}
@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"errors"
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/gin-gonic/gin"
+	"github.com/goccy/go-json"
+	cErr "go-es/internal/errors"
+	"go-es/internal/response"
+	"go-es/internal/service/document/elastic"
+	"go-es/internal/service/document/model/request"
+	docResp "go-es/internal/service/document/model/response"
+	"go-es/logger"
+	"net/http"
+	"time"
+)
+
+// scrollResponse pairs a page of results with the scroll ID the caller must
+// echo back as ScrollID on the next call to SearchScroll to fetch the next
+// page.
+type scrollResponse struct {
+	ScrollID string                 `json:"scroll_id"`
+	Page     docResp.SearchResponse `json:"page"`
+}
+
+// scrollRequest is the SearchScroll body: a SearchRequest to start a new
+// scroll, or a ScrollID alone to advance one already in progress.
+type scrollRequest struct {
+	request.SearchRequest
+	// ScrollID advances an existing scroll started by a previous call; when
+	// empty, the body's SearchRequest fields start a new one.
+	ScrollID string `json:"scroll_id,omitempty"`
+	// KeepAliveSeconds overrides elastic.DefaultScrollKeepAlive for this call.
+	KeepAliveSeconds int `json:"keep_alive_seconds,omitempty"`
+}
+
+// SearchScroll opens or advances a scroll context for paging past the 10k
+// window that from+size pagination can't reach. A body with no ScrollID
+// starts a new scroll from its SearchRequest fields; a body carrying
+// ScrollID advances that scroll to its next page instead. Both cases return
+// a scroll_id to pass back in on the next call. Once a page comes back
+// empty, the scroll context is cleared automatically since there's nothing
+// left to page through.
+func SearchScroll(e *elasticsearch.Client) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		logs := logger.GetLogger(c)
+
+		alias := c.Param("alias")
+		if alias == "" {
+			logs.Error().Msg("alias name is required in URI")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusBadRequest,
+				Message: "alias name is required in URI",
+				Details: "alias name is required in URI",
+				Type:    cErr.BadRequestError.String(),
+			})
+			return
+		}
+
+		var req scrollRequest
+		if c.Request.ContentLength > 0 {
+			if err := c.ShouldBindJSON(&req); err != nil {
+				logs.Error().Err(err).Msg("invalid request payload")
+				response.SendErrorResponse(c, response.ErrResponse{
+					Code:    http.StatusBadRequest,
+					Message: "invalid JSON payload",
+					Details: err.Error(),
+					Type:    cErr.BadRequestError.String(),
+				})
+				return
+			}
+		}
+
+		keepAlive := elastic.DefaultScrollKeepAlive
+		if req.KeepAliveSeconds > 0 {
+			keepAlive = time.Duration(req.KeepAliveSeconds) * time.Second
+		}
+
+		ec := elastic.New(e)
+
+		if req.ScrollID != "" {
+			nextScrollID, page, err := ec.ScrollNext(c.Request.Context(), req.ScrollID, keepAlive)
+			if err != nil {
+				var esErr cErr.ElasticsearchError
+				if errors.As(err, &esErr) {
+					logs.Error().Err(esErr.Details).Msg(esErr.Message)
+					response.SendErrorResponse(c, response.ErrResponse{
+						Code:    esErr.StatusCode,
+						Message: esErr.Message,
+						Details: esErr.Details.Error(),
+						Type:    esErr.Type.String(),
+					})
+					return
+				}
+				logs.Error().Err(err).Msg("failed to advance scroll")
+				response.SendErrorResponse(c, response.ErrResponse{
+					Code:    http.StatusInternalServerError,
+					Message: "failed to advance scroll",
+					Details: err.Error(),
+					Type:    cErr.ServerError.String(),
+				})
+				return
+			}
+
+			if len(page.Documents) == 0 {
+				if err := ec.ClearScroll(c.Request.Context(), nextScrollID); err != nil {
+					logs.Warn().Err(err).Msg("failed to clear exhausted scroll")
+				}
+			}
+
+			c.JSON(http.StatusOK, response.SuccessResponse[scrollResponse]{
+				Ts:      time.Now(),
+				Code:    http.StatusOK,
+				Message: "scroll page",
+				Data:    scrollResponse{ScrollID: nextScrollID, Page: *page},
+			})
+			return
+		}
+
+		query, err := buildQuery(req.SearchRequest)
+		if err != nil {
+			logs.Error().Err(err).Msg("invalid query clause")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusBadRequest,
+				Message: "invalid query clause",
+				Details: err.Error(),
+				Type:    cErr.BadRequestError.String(),
+			})
+			return
+		}
+		if query == nil {
+			query = map[string]interface{}{"match_all": map[string]interface{}{}}
+		}
+
+		size := req.Pagination.Size
+		if size == 0 {
+			size = 10
+		}
+
+		payload, err := json.Marshal(map[string]interface{}{"query": query, "size": size})
+		if err != nil {
+			logs.Error().Err(err).Msg("failed to marshal search body")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusBadRequest,
+				Message: "failed to marshal search body",
+				Details: err.Error(),
+				Type:    cErr.BadRequestError.String(),
+			})
+			return
+		}
+
+		scrollID, page, err := ec.SearchScroll(c.Request.Context(), alias, payload, keepAlive)
+		if err != nil {
+			var esErr cErr.ElasticsearchError
+			if errors.As(err, &esErr) {
+				logs.Error().Err(esErr.Details).Msg(esErr.Message)
+				response.SendErrorResponse(c, response.ErrResponse{
+					Code:    esErr.StatusCode,
+					Message: esErr.Message,
+					Details: esErr.Details.Error(),
+					Type:    esErr.Type.String(),
+				})
+				return
+			}
+			logs.Error().Err(err).Msg("failed to start scroll")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusInternalServerError,
+				Message: "failed to start scroll",
+				Details: err.Error(),
+				Type:    cErr.ServerError.String(),
+			})
+			return
+		}
+
+		if len(page.Documents) == 0 {
+			if err := ec.ClearScroll(c.Request.Context(), scrollID); err != nil {
+				logs.Warn().Err(err).Msg("failed to clear exhausted scroll")
+			}
+		}
+
+		c.JSON(http.StatusOK, response.SuccessResponse[scrollResponse]{
+			Ts:      time.Now(),
+			Code:    http.StatusOK,
+			Message: "scroll started",
+			Data:    scrollResponse{ScrollID: scrollID, Page: *page},
+		})
+	}
+}
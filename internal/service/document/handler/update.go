@@ -6,6 +6,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/goccy/go-json"
 	cErr "go-es/internal/errors"
+	"go-es/internal/notify"
 	"go-es/internal/response"
 	"go-es/internal/service/document/elastic"
 	"go-es/internal/service/document/model/request"
@@ -19,8 +20,9 @@ import (
 // This handler function extracts the index alias and document ID from the URI,
 // parses the JSON request body to get update data, and calls the UpdateDocument
 // function from the elastic package to perform the update. It sends an appropriate
-// JSON response based on the result of the operation.
-func UpdateDocument(e *elasticsearch.Client) func(c *gin.Context) {
+// JSON response based on the result of the operation and, on success, publishes
+// an EventDocumentUpdated to emitter.
+func UpdateDocument(e *elasticsearch.Client, emitter notify.Notifier) func(c *gin.Context) {
 	return func(c *gin.Context) {
 		logs := logger.GetLogger(c)
 
@@ -103,6 +105,17 @@ func UpdateDocument(e *elasticsearch.Client) func(c *gin.Context) {
 		}
 
 		logs.Info().Msg("document updated successfully")
+
+		requestID, actor := requestContext(c)
+		emitter.Publish(c.Request.Context(), notify.Event{
+			EventType:  notify.EventDocumentUpdated,
+			Index:      alias,
+			DocumentID: docID,
+			Ts:         time.Now(),
+			RequestID:  requestID,
+			Actor:      actor,
+		})
+
 		c.JSON(http.StatusOK, response.SuccessResponse[any]{
 			Ts:      time.Now(),
 			Code:    http.StatusOK,
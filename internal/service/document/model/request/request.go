@@ -10,17 +10,73 @@ type UpdateDocumentRequest struct {
 	Data map[string]interface{} `json:"data" binding:"required"` // Fields to update
 }
 
+// ExportMode selects the pagination strategy used by ExportDocuments.
+type ExportMode string
+
+const (
+	// ExportModeScroll uses the deprecated scroll API (default, for
+	// backwards compatibility).
+	ExportModeScroll ExportMode = "scroll"
+	// ExportModePIT uses a Point-in-Time context with search_after, which
+	// has no iteration cap and doesn't hold a scroll context open.
+	ExportModePIT ExportMode = "pit"
+)
+
+// ListMode selects the pagination strategy used by ListAllDocuments.
+type ListMode string
+
+const (
+	// ListModePage uses the existing from/size pagination (default).
+	ListModePage ListMode = "page"
+	// ListModeScroll uses the deprecated scroll API to stream every
+	// document in the index.
+	ListModeScroll ListMode = "scroll"
+	// ListModeSearchAfter uses a Point-in-Time context with search_after to
+	// stream every document without holding a scroll context open.
+	ListModeSearchAfter ListMode = "search_after"
+)
+
+// ExportFormat selects the wire format written by a streamed export.
+type ExportFormat string
+
+const (
+	// ExportFormatNDJSON writes one JSON object per line (default).
+	ExportFormatNDJSON ExportFormat = "ndjson"
+	// ExportFormatJSON writes a single top-level JSON array.
+	ExportFormatJSON ExportFormat = "json"
+	// ExportFormatCSV writes CSV rows using Columns as the header/field list.
+	ExportFormatCSV ExportFormat = "csv"
+)
+
 // ExportDocumentsRequest defines the request structure for exporting documents
 type ExportDocumentsRequest struct {
-	Query map[string]interface{} `json:"query"` // Elasticsearch query for filtering
+	Query   map[string]interface{} `json:"query"`             // Elasticsearch query for filtering
+	Mode    ExportMode             `json:"mode,omitempty"`    // "scroll" (default) or "pit"
+	Format  ExportFormat           `json:"format,omitempty"`  // "ndjson" (default), "json", or "csv"; only used with mode "pit"
+	Columns []string               `json:"columns,omitempty"` // Field list for "csv" format
 }
 
+// ImportFormat selects how ImportDocuments parses the uploaded content.
+type ImportFormat string
+
+const (
+	// ImportFormatJSON treats the input as a single top-level JSON array of
+	// documents (default, for backwards compatibility).
+	ImportFormatJSON ImportFormat = "json"
+	// ImportFormatNDJSON treats the input as one JSON document per line.
+	ImportFormatNDJSON ImportFormat = "ndjson"
+	// ImportFormatBulk treats the input as Elasticsearch bulk format
+	// (alternating metadata/document lines).
+	ImportFormatBulk ImportFormat = "bulk"
+)
+
 // ImportRequest defines the request structure for importing documents.
 type ImportRequest struct {
-	Index string                `form:"index" binding:"required"` // Elasticsearch index name
-	File  *multipart.FileHeader `form:"file"`                     // JSON file (optional)
-	JSON  string                `form:"json"`                     // JSON content (optional)
-	Bulk  bool                  `form:"bulk"`                     // Whether the input is in bulk format
+	Index  string                `form:"index" binding:"required"` // Elasticsearch index name
+	File   *multipart.FileHeader `form:"file"`                     // JSON file (optional)
+	JSON   string                `form:"json"`                     // JSON content (optional)
+	Bulk   bool                  `form:"bulk"`                     // Deprecated alias for format=bulk, kept for backwards compatibility
+	Format ImportFormat          `form:"format"`                   // "json" (default), "ndjson", or "bulk"
 }
 
 // AddDataRequest defines the request structure for adding data to an index
@@ -36,10 +92,118 @@ type SearchRequest struct {
 	Pagination   response.Pagination    `json:"pagination,omitempty"`    // Pagination options
 	MinScore     float64                `json:"min_score,omitempty"`     // Minimum relevance score
 	SearchFields []string               `json:"search_fields,omitempty"` // Fields to search in
+	Sort         []map[string]string    `json:"sort,omitempty"`          // Sort clauses, e.g. [{"created_at":"desc"}]; required for deterministic SearchAfter paging
+	SearchAfter  []interface{}          `json:"search_after,omitempty"`  // Cursor from the last hit's "sort" values, for paging past the from+size window
+	Must         []Clause               `json:"must,omitempty"`          // Clauses that must match and contribute to score
+	Should       []Clause               `json:"should,omitempty"`        // Clauses where at least one should match
+	MustNot      []Clause               `json:"must_not,omitempty"`      // Clauses that must not match
+	Filter       []Clause               `json:"filter,omitempty"`        // Clauses that must match but don't affect score
+	Aggregations map[string]AggSpec     `json:"aggregations,omitempty"`  // Named top-level aggregations to run alongside the query
+}
+
+// RangeClause is the "range" comparison for a single field in a Clause.
+// At least one bound should be set.
+type RangeClause struct {
+	Gte interface{} `json:"gte,omitempty"`
+	Lte interface{} `json:"lte,omitempty"`
+	Gt  interface{} `json:"gt,omitempty"`
+	Lt  interface{} `json:"lt,omitempty"`
+}
+
+// GeoDistanceClause is the "geo_distance" filter for a Clause: documents
+// with Field within Distance (e.g. "10km") of the given point.
+type GeoDistanceClause struct {
+	Field    string  `json:"field" binding:"required"`
+	Distance string  `json:"distance" binding:"required"`
+	Lat      float64 `json:"lat"`
+	Lon      float64 `json:"lon"`
+}
+
+// NestedClause is the "nested" filter for a Clause: Query evaluated against
+// objects under Path.
+type NestedClause struct {
+	Path  string  `json:"path" binding:"required"`
+	Query *Clause `json:"query" binding:"required"`
+}
+
+// Clause is a tagged union of the query clause types accepted by
+// SearchRequest's Must/Should/MustNot/Filter arrays. Exactly one field must
+// be set; the builder rejects a Clause with zero or more than one, so
+// callers get a BadRequestError instead of an opaque Elasticsearch 400.
+type Clause struct {
+	Term        map[string]interface{}   `json:"term,omitempty"`         // Exact-value match on a single field, e.g. {"status": "active"}
+	Terms       map[string][]interface{} `json:"terms,omitempty"`        // Exact-value match against any of a list of values per field
+	Range       map[string]RangeClause   `json:"range,omitempty"`        // Bounded comparison per field
+	Prefix      map[string]interface{}   `json:"prefix,omitempty"`       // Prefix match on a single field
+	Wildcard    map[string]interface{}   `json:"wildcard,omitempty"`     // Wildcard pattern match on a single field
+	Exists      string                   `json:"exists,omitempty"`       // Field name that must be present and non-null
+	GeoDistance *GeoDistanceClause       `json:"geo_distance,omitempty"` // Geo-distance filter
+	Nested      *NestedClause            `json:"nested,omitempty"`       // Nested object query
+}
+
+// AggType names the Elasticsearch aggregation kind an AggSpec builds.
+type AggType string
+
+const (
+	// AggTypeTerms buckets by the distinct values of a field.
+	AggTypeTerms AggType = "terms"
+	// AggTypeDateHistogram buckets a date field into fixed calendar
+	// intervals.
+	AggTypeDateHistogram AggType = "date_histogram"
+	// AggTypeStats computes min/max/avg/sum/count over a numeric field.
+	AggTypeStats AggType = "stats"
+	// AggTypeCardinality estimates the number of distinct values of a field.
+	AggTypeCardinality AggType = "cardinality"
+)
+
+// AggSpec defines a single named entry in SearchRequest.Aggregations.
+type AggSpec struct {
+	Type             AggType `json:"type" binding:"required"`     // "terms", "date_histogram", "stats", or "cardinality"
+	Field            string  `json:"field" binding:"required"`    // Field the aggregation operates on
+	Size             int     `json:"size,omitempty"`              // terms: number of buckets to return
+	CalendarInterval string  `json:"calendar_interval,omitempty"` // date_histogram: e.g. "day", "week", "month"
+	Format           string  `json:"format,omitempty"`            // date_histogram: format used to render bucket keys
+}
+
+// SuggestMode selects which Elasticsearch suggester/field AutoComplete
+// targets. It should match how the field was configured via
+// model.FieldConfig.AutocompleteMode when the index was created.
+type SuggestMode string
+
+const (
+	// SuggestModeCompletion queries the completion suggester on the
+	// field's "suggest" sub-field (default).
+	SuggestModeCompletion SuggestMode = "completion"
+	// SuggestModeEdgeNgram matches the edge_ngram-analyzed field directly.
+	SuggestModeEdgeNgram SuggestMode = "edge_ngram"
+	// SuggestModeSearchAsYouType runs a bool_prefix multi_match against a
+	// "search_as_you_type" field.
+	SuggestModeSearchAsYouType SuggestMode = "search_as_you_type"
+	// SuggestModePhrase queries the phrase suggester for did-you-mean
+	// style corrections instead of prefix completion.
+	SuggestModePhrase SuggestMode = "phrase"
+)
+
+// GeoPoint is a latitude/longitude pair for a completion suggester's geo
+// context filter.
+type GeoPoint struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
 }
 
 // SuggestRequest defines the request structure for autocomplete suggestions
 type SuggestRequest struct {
-	Field string `json:"field" binding:"required"` // Field to suggest on
-	Input string `json:"input" binding:"required"` // Input text for suggestions
+	Field                string              `json:"field" binding:"required"`         // Field to suggest on
+	Input                string              `json:"input" binding:"required"`         // Input text for suggestions
+	Mode                 SuggestMode         `json:"mode,omitempty"`                   // Strategy to query; defaults to "completion"
+	Fuzziness            string              `json:"fuzziness,omitempty"`              // e.g. "AUTO", "1", "2" (completion/phrase)
+	FuzzyTranspositions  *bool               `json:"fuzzy_transpositions,omitempty"`   // Completion fuzzy: whether adjacent transpositions count as one edit (default true in Elasticsearch)
+	FuzzyPrefixLength    int                 `json:"fuzzy_prefix_length,omitempty"`    // Completion fuzzy: number of leading characters exempt from fuzziness
+	MinLength            int                 `json:"min_length,omitempty"`             // Minimum input length before suggesting (edge_ngram/search_as_you_type)
+	ContextCategory      string              `json:"context_category,omitempty"`       // Completion context-suggester category filter (shorthand for Contexts["category"])
+	ContextGeo           *GeoPoint           `json:"context_geo,omitempty"`            // Completion context-suggester geo filter
+	Contexts             map[string][]string `json:"contexts,omitempty"`               // Completion context-suggester filters by context name, e.g. {"category": ["electronics"]}
+	Confidence           float64             `json:"confidence,omitempty"`             // Phrase suggester: minimum score relative to the input before a correction is returned
+	MaxErrors            float64             `json:"max_errors,omitempty"`             // Phrase suggester: maximum number/percentage of terms considered for correction
+	DirectGeneratorField string              `json:"direct_generator_field,omitempty"` // Phrase suggester: field to draw correction candidates from (defaults to Field)
 }
@@ -8,9 +8,10 @@ type Pagination struct {
 
 // SearchResponse defines the response structure for search results
 type SearchResponse struct {
-	Total     int              `json:"total"`     // Total number of matching documents
-	MaxScore  float64          `json:"max_score"` // Maximum score of the matching documents
-	Documents []SearchDocument `json:"documents"` // List of matching documents
+	Total        int                    `json:"total"`                  // Total number of matching documents
+	MaxScore     float64                `json:"max_score"`              // Maximum score of the matching documents
+	Documents    []SearchDocument       `json:"documents"`              // List of matching documents
+	Aggregations map[string]interface{} `json:"aggregations,omitempty"` // Raw Elasticsearch aggregation results, keyed by aggregation name
 }
 
 // SearchDocument defines the structure of a single document in the search results
@@ -0,0 +1,36 @@
+package health
+
+import "time"
+
+// Config is the health-check subsystem configuration, loaded under the
+// top-level `health_check` key.
+type Config struct {
+	Interval           time.Duration `mapstructure:"interval"`            // how often to poll cluster health
+	Timeout            time.Duration `mapstructure:"timeout"`             // per-check timeout
+	UnhealthyThreshold int           `mapstructure:"unhealthy_threshold"` // consecutive failures before /readyz returns 503
+}
+
+// defaultConfig returns the values used for any zero field left in a
+// caller-supplied Config.
+func defaultConfig() Config {
+	return Config{
+		Interval:           30 * time.Second,
+		Timeout:            5 * time.Second,
+		UnhealthyThreshold: 3,
+	}
+}
+
+// withDefaults fills zero-valued fields in cfg from defaultConfig.
+func withDefaults(cfg Config) Config {
+	def := defaultConfig()
+	if cfg.Interval <= 0 {
+		cfg.Interval = def.Interval
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = def.Timeout
+	}
+	if cfg.UnhealthyThreshold <= 0 {
+		cfg.UnhealthyThreshold = def.UnhealthyThreshold
+	}
+	return cfg
+}
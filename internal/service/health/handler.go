@@ -0,0 +1,49 @@
+package health
+
+import (
+	"github.com/gin-gonic/gin"
+	"go-es/internal/esc"
+	"net/http"
+)
+
+// Readyz reports 503 once the package-level Checker has failed
+// UnhealthyThreshold consecutive checks, and 200 otherwise. The response
+// also reports the cluster version negotiated by esc.NewClientWithVersionCheck
+// at startup, so operators can see what the service is actually talking to
+// without a separate call to GET /_cluster/version.
+func Readyz() func(*gin.Context) {
+	return func(c *gin.Context) {
+		status := GetStatus()
+		if !status.Ready {
+			c.JSON(http.StatusServiceUnavailable, gin.H{
+				"status":          "not_ready",
+				"cluster_status":  status.ClusterStatus,
+				"cluster_version": clusterVersionNumber(),
+			})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"status":          "ready",
+			"cluster_status":  status.ClusterStatus,
+			"cluster_version": clusterVersionNumber(),
+		})
+	}
+}
+
+// clusterVersionNumber returns the dotted version number detected at
+// startup, or "" if the client was constructed without a version check.
+func clusterVersionNumber() string {
+	if v := esc.DetectedVersion(); v != nil {
+		return v.Number
+	}
+	return ""
+}
+
+// Livez always reports 200 once the process is up. It's kept separate from
+// Readyz so a degraded cluster doesn't get the process restarted by an
+// orchestrator that only checks liveness.
+func Livez() func(*gin.Context) {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "alive"})
+	}
+}
@@ -0,0 +1,177 @@
+// Package health periodically polls an Elasticsearch cluster's health and
+// feeds a Gin readiness/liveness endpoint, so the process can be pulled out
+// of rotation without an external sidecar.
+package health
+
+import (
+	"context"
+	"fmt"
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/goccy/go-json"
+	"github.com/rs/zerolog/log"
+	"sync/atomic"
+	"time"
+)
+
+// Status is a snapshot of the most recent health check.
+type Status struct {
+	ClusterStatus string    // "green", "yellow", or "red"
+	ActiveShards  int       // active_shards from _cluster/health
+	PendingTasks  int       // number_of_pending_tasks from _cluster/health
+	LatencyMillis int64     // round-trip time of the last check
+	Ready         bool      // false once UnhealthyThreshold checks have failed in a row
+	CheckedAt     time.Time // when this snapshot was taken
+	Err           error     // error from the last check, if any
+}
+
+// Checker periodically calls _cluster/health, tracking consecutive
+// failures so the process can flip not-ready after cfg.UnhealthyThreshold
+// failures and flip back on recovery.
+type Checker struct {
+	client *elasticsearch.Client
+	cfg    Config
+
+	status atomic.Pointer[Status]
+	fails  atomic.Int32
+
+	stop chan struct{}
+}
+
+// NewChecker creates a Checker for client using cfg. Zero-valued fields in
+// cfg fall back to defaultConfig.
+func NewChecker(client *elasticsearch.Client, cfg Config) *Checker {
+	c := &Checker{client: client, cfg: withDefaults(cfg), stop: make(chan struct{})}
+	c.status.Store(&Status{Ready: true})
+	return c
+}
+
+// Run polls on a ticker until ctx is cancelled or Stop is called. Callers
+// should invoke it with `go`.
+func (c *Checker) Run(ctx context.Context) {
+	c.check(ctx)
+
+	ticker := time.NewTicker(c.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.stop:
+			return
+		case <-ticker.C:
+			c.check(ctx)
+		}
+	}
+}
+
+// Stop halts the polling loop started by Run.
+func (c *Checker) Stop() {
+	close(c.stop)
+}
+
+// Status returns the most recent health check result.
+func (c *Checker) Status() Status {
+	return *c.status.Load()
+}
+
+// Ready reports whether the cluster is currently considered ready, i.e.
+// hasn't failed cfg.UnhealthyThreshold consecutive checks.
+func (c *Checker) Ready() bool {
+	return c.status.Load().Ready
+}
+
+func (c *Checker) check(ctx context.Context) {
+	checkCtx, cancel := context.WithTimeout(ctx, c.cfg.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	clusterStatus, activeShards, pendingTasks, err := c.queryClusterHealth(checkCtx)
+	latency := time.Since(start)
+
+	next := Status{
+		ClusterStatus: clusterStatus,
+		ActiveShards:  activeShards,
+		PendingTasks:  pendingTasks,
+		LatencyMillis: latency.Milliseconds(),
+		CheckedAt:     time.Now(),
+		Err:           err,
+	}
+
+	if err != nil {
+		fails := c.fails.Add(1)
+		next.Ready = fails < int32(c.cfg.UnhealthyThreshold)
+		if !next.Ready {
+			log.Error().Err(err).Int32("consecutive_failures", fails).Msg("elasticsearch cluster health check failing, marking not ready")
+		}
+	} else {
+		if c.fails.Swap(0) >= int32(c.cfg.UnhealthyThreshold) {
+			log.Info().Msg("elasticsearch cluster health check recovered, marking ready")
+		}
+		next.Ready = true
+	}
+
+	c.status.Store(&next)
+}
+
+// queryClusterHealth calls _cluster/health and extracts the fields the
+// checker tracks.
+func (c *Checker) queryClusterHealth(ctx context.Context) (clusterStatus string, activeShards int, pendingTasks int, err error) {
+	res, err := c.client.Cluster.Health(c.client.Cluster.Health.WithContext(ctx))
+	if err != nil {
+		return "", 0, 0, err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return "", 0, 0, fmt.Errorf("cluster health check returned status %s", res.Status())
+	}
+
+	var body struct {
+		Status               string `json:"status"`
+		ActiveShards         int    `json:"active_shards"`
+		NumberOfPendingTasks int    `json:"number_of_pending_tasks"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return "", 0, 0, err
+	}
+
+	return body.Status, body.ActiveShards, body.NumberOfPendingTasks, nil
+}
+
+// defaultChecker is the package-level Checker read by Ready/GetStatus and
+// the HTTP handlers. It's nil until Initialize is called, mirroring
+// notify.Initialize's global-init pattern; a nil defaultChecker reports
+// ready so health checks are opt-in.
+var defaultChecker *Checker
+
+// Initialize creates a Checker for client using cfg and starts polling in
+// a background goroutine tied to ctx. Call once at startup; a nil cfg
+// disables health checking and leaves /readyz always-ready.
+func Initialize(ctx context.Context, client *elasticsearch.Client, cfg *Config) {
+	if cfg == nil {
+		return
+	}
+	defaultChecker = NewChecker(client, *cfg)
+	go defaultChecker.Run(ctx)
+}
+
+// Ready reports whether the package-level Checker installed by Initialize
+// considers the cluster ready. Returns true when health checking hasn't
+// been initialized.
+func Ready() bool {
+	if defaultChecker == nil {
+		return true
+	}
+	return defaultChecker.Ready()
+}
+
+// GetStatus returns the most recent Status from the package-level Checker.
+// Returns a ready zero-value Status when health checking hasn't been
+// initialized.
+func GetStatus() Status {
+	if defaultChecker == nil {
+		return Status{Ready: true}
+	}
+	return defaultChecker.Status()
+}
@@ -0,0 +1,50 @@
+package health
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strings"
+)
+
+// Metrics exposes the most recent health check as Prometheus text
+// exposition, mirroring indicesHandler.Metrics()'s hand-rolled format.
+func Metrics() func(*gin.Context) {
+	return func(c *gin.Context) {
+		c.Header("Content-Type", "text/plain; version=0.0.4")
+
+		status := GetStatus()
+
+		var b strings.Builder
+		fmt.Fprintf(&b, "# HELP es_cluster_status Elasticsearch cluster status: 0=red, 1=yellow, 2=green.\n")
+		fmt.Fprintf(&b, "# TYPE es_cluster_status gauge\n")
+		fmt.Fprintf(&b, "es_cluster_status %d\n", clusterStatusCode(status.ClusterStatus))
+
+		fmt.Fprintf(&b, "# HELP es_active_shards Number of active shards reported by the last cluster health check.\n")
+		fmt.Fprintf(&b, "# TYPE es_active_shards gauge\n")
+		fmt.Fprintf(&b, "es_active_shards %d\n", status.ActiveShards)
+
+		fmt.Fprintf(&b, "# HELP es_pending_tasks Number of pending cluster tasks reported by the last health check.\n")
+		fmt.Fprintf(&b, "# TYPE es_pending_tasks gauge\n")
+		fmt.Fprintf(&b, "es_pending_tasks %d\n", status.PendingTasks)
+
+		fmt.Fprintf(&b, "# HELP es_ping_latency_ms Latency of the last cluster health check, in milliseconds.\n")
+		fmt.Fprintf(&b, "# TYPE es_ping_latency_ms gauge\n")
+		fmt.Fprintf(&b, "es_ping_latency_ms %d\n", status.LatencyMillis)
+
+		c.String(http.StatusOK, b.String())
+	}
+}
+
+// clusterStatusCode maps an Elasticsearch cluster status string to the
+// gauge value es_cluster_status reports.
+func clusterStatusCode(clusterStatus string) int {
+	switch clusterStatus {
+	case "green":
+		return 2
+	case "yellow":
+		return 1
+	default:
+		return 0
+	}
+}
@@ -13,12 +13,7 @@ import (
 func (e *elastic) CheckIndex(ctx context.Context, index string) (bool, error) {
 	res, err := e.client.Indices.Exists([]string{index}, e.client.Indices.Exists.WithContext(ctx))
 	if err != nil {
-		return false, errors.ElasticsearchError{
-			StatusCode: http.StatusInternalServerError,
-			Message:    "failed to connect to elastic server",
-			Details:    err,
-			Type:       errors.ConnectionError,
-		}
+		return false, errors.NewConnectionError(err)
 	}
 	defer res.Body.Close()
 
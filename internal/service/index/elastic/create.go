@@ -3,7 +3,9 @@ package elastic
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"go-es/internal/errors"
+	"go-es/internal/esc"
 	"net/http"
 )
 
@@ -12,6 +14,22 @@ import (
 // It sends an index creation request to the Elasticsearch server using the provided index name
 // and payload, and returns an error if the operation fails.
 func (e *elastic) CreateIndex(ctx context.Context, index string, payload []byte) error {
+	// e.version is normally already gated by esc.NewClientWithVersionCheck at
+	// startup; this re-checks it so a client constructed another way (e.g.
+	// via esc.NewClient directly, or a stale detected version) still rejects
+	// an unsupported cluster here instead of sending a mapping payload that
+	// assumes typeless 7.x/8.x mappings.
+	if e.version != nil {
+		if ok, err := e.version.AtLeast(esc.DefaultMinVersion); err == nil && !ok {
+			return errors.ElasticsearchError{
+				StatusCode: http.StatusPreconditionFailed,
+				Message:    "cluster version is below the minimum supported version",
+				Details:    fmt.Errorf("cluster version %s is below the minimum supported version %s", e.version.Number, esc.DefaultMinVersion),
+				Type:       errors.UnsupportedVersionError,
+			}
+		}
+	}
+
 	// Send index creation request
 	res, err := e.client.Indices.Create(
 		index,
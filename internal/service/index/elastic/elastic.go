@@ -3,6 +3,8 @@ package elastic
 import (
 	"context"
 	"github.com/elastic/go-elasticsearch/v8"
+	"go-es/internal/esc"
+	"time"
 )
 
 // Elastic is an interface to interact with Elasticsearch
@@ -19,17 +21,65 @@ type Elastic interface {
 	ListIndices(ctx context.Context) (map[string]string, error)
 	// UpdateIndexMappings updates the mappings of an existing index.
 	UpdateIndexMappings(ctx context.Context, index string, payload []byte) error
-	// Reindex copies data from the old index to a new index with updated mappings.
-	Reindex(ctx context.Context, oldIndex, newIndex string, payload []byte) error
 	// UpdateAlias updates the alias of an index on Elasticsearch.
 	UpdateAlias(ctx context.Context, alias, newIndex string) error
 	// ResolveAlias resolves the index of an alias on Elasticsearch.
 	ResolveAlias(ctx context.Context, alias string) (string, error)
+	// StartReindex creates destIndex with the supplied mapping and submits an
+	// asynchronous `_reindex` task from srcIndex, returning the task ID.
+	StartReindex(ctx context.Context, srcIndex, destIndex string, mapping []byte, opts ReindexOptions) (taskID string, err error)
+	// GetReindexStatus polls `_tasks/{id}` for the progress of a task started
+	// via StartReindex.
+	GetReindexStatus(ctx context.Context, taskID string) (*ReindexTaskStatus, error)
+	// SwapAlias atomically moves alias from oldIndex to newIndex.
+	SwapAlias(ctx context.Context, alias, oldIndex, newIndex string) error
+	// CancelReindexTask requests cancellation of a running task.
+	CancelReindexTask(ctx context.Context, taskID string) error
+	// RollbackReindex cancels taskID (if still running) and deletes destIndex,
+	// undoing a reindex that failed or exceeded its failure threshold before
+	// the alias was ever swapped.
+	RollbackReindex(ctx context.Context, taskID, destIndex string) error
+	// RemoveAlias detaches alias from index without attaching it anywhere
+	// else, the soft-delete counterpart to UpdateAlias's add-only behavior.
+	RemoveAlias(ctx context.Context, alias, index string) error
+	// WriteTombstone records that t.Index was detached from t.Alias and
+	// should be physically deleted once t.DeleteAt elapses.
+	WriteTombstone(ctx context.Context, t Tombstone) error
+	// GetTombstone retrieves the tombstone recorded for index, if any.
+	GetTombstone(ctx context.Context, index string) (*Tombstone, error)
+	// DeleteTombstone removes the tombstone recorded for index.
+	DeleteTombstone(ctx context.Context, index string) error
+	// FindTombstoneByAlias returns the most recently tombstoned index for
+	// alias, for RestoreAlias.
+	FindTombstoneByAlias(ctx context.Context, alias string) (*Tombstone, error)
+	// ListDueTombstones returns up to 1000 tombstones whose DeleteAt is at
+	// or before before, for the background sweeper to physically delete.
+	ListDueTombstones(ctx context.Context, before time.Time) ([]Tombstone, error)
+	// RestoreAlias re-attaches alias to its most recently tombstoned index
+	// and clears the tombstone, undoing the DeleteIndex handler's soft delete
+	// before the grace period expires. It returns the restored physical index
+	// name.
+	RestoreAlias(ctx context.Context, alias string) (string, error)
 }
 type elastic struct {
-	client *elasticsearch.Client
+	client  *elasticsearch.Client
+	version *esc.ClusterVersion
 }
 
+// New creates a new instance of Elastic bound to client. It captures the
+// cluster version most recently detected by esc.NewClientWithVersionCheck
+// (nil if the check was never run, e.g. in a test) so version-sensitive
+// calls have a hook to branch on without the caller threading the version
+// through every call.
+//
+// Today that hook is used as a gate, not a format switch: since
+// esc.DefaultMinVersion already excludes pre-7.0 clusters (see
+// NewClientWithVersionCheck), CreateIndex and UpdateIndexMappings can emit
+// a single typeless mapping body on every supported version instead of
+// maintaining a 6.x-typed and a 7.x/8.x-typeless variant; they just re-check
+// e.version first and reject anything below the minimum. There is currently
+// no ES operation used by this package whose wire shape actually differs
+// between 7.x and 8.x, so there's no elasticV7/elasticV8 split to make.
 func New(client *elasticsearch.Client) Elastic {
-	return &elastic{client: client}
+	return &elastic{client: client, version: esc.DetectedVersion()}
 }
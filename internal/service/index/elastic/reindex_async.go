@@ -0,0 +1,254 @@
+package elastic
+
+import (
+	"bytes"
+	"context"
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+	"github.com/goccy/go-json"
+	"go-es/internal/errors"
+	"go-es/internal/notify"
+	"net/http"
+	"time"
+)
+
+// ReindexOptions controls the behavior of an asynchronous StartReindex call.
+type ReindexOptions struct {
+	Conflicts         string      `json:"conflicts,omitempty"`           // "proceed" or "abort" (default)
+	Refresh           bool        `json:"refresh,omitempty"`             // refresh the destination index once the reindex completes
+	RequestsPerSecond float64     `json:"requests_per_second,omitempty"` // throttle, 0 means unlimited
+	Slices            interface{} `json:"slices,omitempty"`              // "auto" or an integer slice count
+	Script            string      `json:"script,omitempty"`              // optional inline painless script to transform documents
+}
+
+// ReindexTaskStatus reports the progress of a task started via StartReindex,
+// as returned by polling _tasks/{id}.
+type ReindexTaskStatus struct {
+	Completed        bool  `json:"completed"`
+	Total            int64 `json:"total"`
+	Updated          int64 `json:"updated"`
+	Created          int64 `json:"created"`
+	Deleted          int64 `json:"deleted"`
+	VersionConflicts int64 `json:"version_conflicts"`
+	ThrottledMillis  int64 `json:"throttled_millis"`
+	Failures         int64 `json:"failures"`
+}
+
+// StartReindex creates destIndex with the supplied mapping and submits a
+// `_reindex?wait_for_completion=false` request from srcIndex into it,
+// returning the Elasticsearch task ID so progress can be polled via
+// GetReindexStatus. The caller is responsible for swapping the alias once
+// the task completes (see SwapAlias).
+func (e *elastic) StartReindex(ctx context.Context, srcIndex, destIndex string, mapping []byte, opts ReindexOptions) (string, error) {
+	if err := e.CreateIndex(ctx, destIndex, mapping); err != nil {
+		return "", err
+	}
+
+	notify.Publish(ctx, notify.Event{
+		EventType: notify.EventIndexCreated,
+		Index:     destIndex,
+		Ts:        time.Now(),
+	})
+
+	conflicts := opts.Conflicts
+	if conflicts == "" {
+		conflicts = "abort"
+	}
+
+	body := map[string]interface{}{
+		"source":    map[string]interface{}{"index": srcIndex},
+		"dest":      map[string]interface{}{"index": destIndex},
+		"conflicts": conflicts,
+	}
+	if opts.Script != "" {
+		body["script"] = map[string]interface{}{
+			"source": opts.Script,
+			"lang":   "painless",
+		}
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return "", errors.ElasticsearchError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "failed to marshal reindex body",
+			Details:    err,
+			Type:       errors.MarhshalingError,
+		}
+	}
+
+	reindexOpts := []func(*esapi.ReindexRequest){
+		e.client.Reindex.WithContext(ctx),
+		e.client.Reindex.WithWaitForCompletion(false),
+		e.client.Reindex.WithRefresh(opts.Refresh),
+	}
+	if opts.RequestsPerSecond > 0 {
+		reindexOpts = append(reindexOpts, e.client.Reindex.WithRequestsPerSecond(int(opts.RequestsPerSecond)))
+	}
+	if opts.Slices != nil {
+		reindexOpts = append(reindexOpts, e.client.Reindex.WithSlices(opts.Slices))
+	}
+
+	res, err := e.client.Reindex(bytes.NewReader(payload), reindexOpts...)
+	if err != nil {
+		return "", errors.NewConnectionError(err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return "", errors.ParseElasticsearchError(res, "failed to start reindex task")
+	}
+
+	var result struct {
+		Task string `json:"task"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return "", errors.ElasticsearchError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "failed to decode reindex response",
+			Details:    err,
+			Type:       errors.DecodeError,
+		}
+	}
+
+	return result.Task, nil
+}
+
+// GetReindexStatus polls `_tasks/{id}` and reports the progress of a reindex
+// task started via StartReindex.
+func (e *elastic) GetReindexStatus(ctx context.Context, taskID string) (*ReindexTaskStatus, error) {
+	res, err := e.client.Tasks.Get(taskID, e.client.Tasks.Get.WithContext(ctx))
+	if err != nil {
+		return nil, errors.NewConnectionError(err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, errors.ParseElasticsearchError(res, "failed to get task status")
+	}
+
+	var result struct {
+		Completed bool `json:"completed"`
+		Task      struct {
+			Status struct {
+				Total            int64             `json:"total"`
+				Updated          int64             `json:"updated"`
+				Created          int64             `json:"created"`
+				Deleted          int64             `json:"deleted"`
+				VersionConflicts int64             `json:"version_conflicts"`
+				ThrottledMillis  int64             `json:"throttled_millis"`
+				Failures         []json.RawMessage `json:"failures"`
+			} `json:"status"`
+		} `json:"task"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, errors.ElasticsearchError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "failed to decode task status response",
+			Details:    err,
+			Type:       errors.DecodeError,
+		}
+	}
+
+	return &ReindexTaskStatus{
+		Completed:        result.Completed,
+		Total:            result.Task.Status.Total,
+		Updated:          result.Task.Status.Updated,
+		Created:          result.Task.Status.Created,
+		Deleted:          result.Task.Status.Deleted,
+		VersionConflicts: result.Task.Status.VersionConflicts,
+		ThrottledMillis:  result.Task.Status.ThrottledMillis,
+		Failures:         int64(len(result.Task.Status.Failures)),
+	}, nil
+}
+
+// RollbackReindex aborts an in-flight or finished-with-failures reindex:
+// it requests cancellation of taskID (cooperative, best-effort) and deletes
+// destIndex, the half-built destination index. The alias is never touched
+// here, since StartReindex doesn't move it until SwapAlias is called
+// explicitly, so the source index is left serving traffic untouched.
+func (e *elastic) RollbackReindex(ctx context.Context, taskID, destIndex string) error {
+	if taskID != "" {
+		status, err := e.GetReindexStatus(ctx, taskID)
+		if err != nil {
+			return err
+		}
+		if !status.Completed {
+			if err := e.CancelReindexTask(ctx, taskID); err != nil {
+				return err
+			}
+		}
+	}
+	if err := e.DeleteIndex(ctx, destIndex); err != nil {
+		return err
+	}
+
+	notify.Publish(ctx, notify.Event{
+		EventType: notify.EventIndexRolledBack,
+		Index:     destIndex,
+		Ts:        time.Now(),
+	})
+
+	return nil
+}
+
+// CancelReindexTask requests cancellation of a running task via
+// `_tasks/{id}/_cancel`. Elasticsearch cancellation is cooperative: the task
+// stops at its next checkpoint rather than immediately.
+func (e *elastic) CancelReindexTask(ctx context.Context, taskID string) error {
+	res, err := e.client.Tasks.Cancel(
+		e.client.Tasks.Cancel.WithContext(ctx),
+		e.client.Tasks.Cancel.WithTaskID(taskID),
+	)
+	if err != nil {
+		return errors.NewConnectionError(err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.ParseElasticsearchError(res, "failed to cancel task")
+	}
+
+	return nil
+}
+
+// SwapAlias atomically removes alias from oldIndex and adds it to newIndex
+// in a single `_aliases` request, so the alias is never briefly unresolved.
+func (e *elastic) SwapAlias(ctx context.Context, alias, oldIndex, newIndex string) error {
+	body := map[string]interface{}{
+		"actions": []map[string]interface{}{
+			{"remove": map[string]interface{}{"index": oldIndex, "alias": alias}},
+			{"add": map[string]interface{}{"index": newIndex, "alias": alias}},
+		},
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return errors.ElasticsearchError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "failed to marshal alias swap body",
+			Details:    err,
+			Type:       errors.MarhshalingError,
+		}
+	}
+
+	res, err := e.client.Indices.UpdateAliases(
+		bytes.NewReader(payload),
+		e.client.Indices.UpdateAliases.WithContext(ctx),
+	)
+	if err != nil {
+		return errors.NewConnectionError(err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.ParseElasticsearchError(res, "failed to swap alias")
+	}
+
+	notify.Publish(ctx, notify.Event{
+		EventType: notify.EventIndexReindexed,
+		Index:     alias,
+		Ts:        time.Now(),
+	})
+
+	return nil
+}
@@ -18,12 +18,7 @@ func (e *elastic) ResolveAlias(ctx context.Context, alias string) (string, error
 		e.client.Indices.GetAlias.WithName(alias),
 	)
 	if err != nil {
-		return "", errors.ElasticsearchError{
-			StatusCode: http.StatusInternalServerError,
-			Message:    "failed to connect to elastic server",
-			Details:    err,
-			Type:       errors.ConnectionError,
-		}
+		return "", errors.NewConnectionError(err)
 	}
 	defer res.Body.Close()
 
@@ -0,0 +1,254 @@
+package elastic
+
+import (
+	"bytes"
+	"context"
+	"github.com/goccy/go-json"
+	"go-es/internal/errors"
+	"net/http"
+	"time"
+)
+
+// tombstoneIndex is the internal state index backing soft-deleted indices:
+// the DeleteIndex handler writes one document here instead of dropping the physical
+// index immediately, and the background sweeper started from
+// server.NewServer deletes it for real once DeleteAt elapses.
+const tombstoneIndex = ".go-es-tombstones"
+
+// Tombstone records an index that has been detached from its alias and is
+// pending physical deletion.
+type Tombstone struct {
+	Alias        string    `json:"alias"`
+	Index        string    `json:"index"`
+	TombstonedAt time.Time `json:"tombstoned_at"`
+	DeleteAt     time.Time `json:"delete_at"`
+}
+
+// WriteTombstone records that t.Index was detached from t.Alias and should
+// be physically deleted once t.DeleteAt elapses. The document ID is
+// t.Index, so RestoreAlias and the sweeper can both address it directly.
+// Elasticsearch creates tombstoneIndex automatically on first write.
+func (e *elastic) WriteTombstone(ctx context.Context, t Tombstone) error {
+	body, err := json.Marshal(t)
+	if err != nil {
+		return errors.ElasticsearchError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "failed to marshal tombstone",
+			Details:    err,
+			Type:       errors.MarhshalingError,
+		}
+	}
+
+	res, err := e.client.Index(
+		tombstoneIndex,
+		bytes.NewReader(body),
+		e.client.Index.WithContext(ctx),
+		e.client.Index.WithDocumentID(t.Index),
+		e.client.Index.WithRefresh("true"),
+	)
+	if err != nil {
+		return errors.NewConnectionError(err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.ParseElasticsearchError(res, "failed to write tombstone")
+	}
+	return nil
+}
+
+// GetTombstone retrieves the tombstone recorded for index, if any.
+func (e *elastic) GetTombstone(ctx context.Context, index string) (*Tombstone, error) {
+	res, err := e.client.Get(tombstoneIndex, index, e.client.Get.WithContext(ctx))
+	if err != nil {
+		return nil, errors.NewConnectionError(err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, errors.ElasticsearchError{
+			StatusCode: http.StatusNotFound,
+			Message:    "tombstone not found",
+			Details:    err,
+			Type:       errors.NotFoundError,
+		}
+	}
+	if res.IsError() {
+		return nil, errors.ParseElasticsearchError(res, "failed to get tombstone")
+	}
+
+	var doc struct {
+		Source Tombstone `json:"_source"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&doc); err != nil {
+		return nil, errors.ElasticsearchError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "failed to decode tombstone",
+			Details:    err,
+			Type:       errors.DecodeError,
+		}
+	}
+	return &doc.Source, nil
+}
+
+// FindTombstoneByAlias returns the most recently tombstoned index for
+// alias, so RestoreAlias can re-attach the alias without the caller having
+// to know the physical index name.
+func (e *elastic) FindTombstoneByAlias(ctx context.Context, alias string) (*Tombstone, error) {
+	query := map[string]interface{}{
+		"size":  1,
+		"sort":  []map[string]interface{}{{"tombstoned_at": "desc"}},
+		"query": map[string]interface{}{"term": map[string]interface{}{"alias.keyword": alias}},
+	}
+	payload, err := json.Marshal(query)
+	if err != nil {
+		return nil, errors.ElasticsearchError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "failed to marshal tombstone query",
+			Details:    err,
+			Type:       errors.MarhshalingError,
+		}
+	}
+
+	hits, err := e.searchTombstones(ctx, payload)
+	if err != nil {
+		return nil, err
+	}
+	if len(hits) == 0 {
+		return nil, errors.ElasticsearchError{
+			StatusCode: http.StatusNotFound,
+			Message:    "no tombstone found for alias",
+			Details:    err,
+			Type:       errors.NotFoundError,
+		}
+	}
+	return &hits[0], nil
+}
+
+// ListDueTombstones returns up to 1000 tombstones whose DeleteAt is at or
+// before before, for the background sweeper to physically delete.
+func (e *elastic) ListDueTombstones(ctx context.Context, before time.Time) ([]Tombstone, error) {
+	query := map[string]interface{}{
+		"size":  1000,
+		"query": map[string]interface{}{"range": map[string]interface{}{"delete_at": map[string]interface{}{"lte": before}}},
+	}
+	payload, err := json.Marshal(query)
+	if err != nil {
+		return nil, errors.ElasticsearchError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "failed to marshal tombstone query",
+			Details:    err,
+			Type:       errors.MarhshalingError,
+		}
+	}
+	return e.searchTombstones(ctx, payload)
+}
+
+// searchTombstones runs payload against tombstoneIndex and decodes the hits'
+// _source into Tombstone. A missing tombstoneIndex (no deletes have
+// happened yet) is treated as zero results rather than an error.
+func (e *elastic) searchTombstones(ctx context.Context, payload []byte) ([]Tombstone, error) {
+	res, err := e.client.Search(
+		e.client.Search.WithContext(ctx),
+		e.client.Search.WithIndex(tombstoneIndex),
+		e.client.Search.WithBody(bytes.NewReader(payload)),
+		e.client.Search.WithIgnoreUnavailable(true),
+	)
+	if err != nil {
+		return nil, errors.NewConnectionError(err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, errors.ParseElasticsearchError(res, "failed to search tombstones")
+	}
+
+	var esResponse struct {
+		Hits struct {
+			Hits []struct {
+				Source Tombstone `json:"_source"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&esResponse); err != nil {
+		return nil, errors.ElasticsearchError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "failed to decode tombstone search response",
+			Details:    err,
+			Type:       errors.DecodeError,
+		}
+	}
+
+	tombstones := make([]Tombstone, 0, len(esResponse.Hits.Hits))
+	for _, hit := range esResponse.Hits.Hits {
+		tombstones = append(tombstones, hit.Source)
+	}
+	return tombstones, nil
+}
+
+// DeleteTombstone removes the tombstone recorded for index, e.g. after
+// RestoreAlias re-attaches the alias or the sweeper has physically deleted
+// the index.
+func (e *elastic) DeleteTombstone(ctx context.Context, index string) error {
+	res, err := e.client.Delete(tombstoneIndex, index, e.client.Delete.WithContext(ctx))
+	if err != nil {
+		return errors.NewConnectionError(err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() && res.StatusCode != http.StatusNotFound {
+		return errors.ParseElasticsearchError(res, "failed to delete tombstone")
+	}
+	return nil
+}
+
+// RemoveAlias detaches alias from index without attaching it anywhere else,
+// the soft-delete counterpart to UpdateAlias's add-only behavior.
+func (e *elastic) RemoveAlias(ctx context.Context, alias, index string) error {
+	body := map[string]interface{}{
+		"actions": []map[string]interface{}{
+			{"remove": map[string]interface{}{"index": index, "alias": alias}},
+		},
+	}
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return errors.ElasticsearchError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "failed to marshal alias body",
+			Details:    err,
+			Type:       errors.MarhshalingError,
+		}
+	}
+
+	res, err := e.client.Indices.UpdateAliases(
+		bytes.NewReader(payload),
+		e.client.Indices.UpdateAliases.WithContext(ctx),
+	)
+	if err != nil {
+		return errors.NewConnectionError(err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.ParseElasticsearchError(res, "failed to remove alias")
+	}
+	return nil
+}
+
+// RestoreAlias re-attaches alias to its most recently tombstoned index and
+// clears the tombstone, undoing the DeleteIndex handler's soft delete before
+// the grace period expires.
+func (e *elastic) RestoreAlias(ctx context.Context, alias string) (string, error) {
+	t, err := e.FindTombstoneByAlias(ctx, alias)
+	if err != nil {
+		return "", err
+	}
+
+	if err := e.UpdateAlias(ctx, alias, t.Index); err != nil {
+		return "", err
+	}
+	if err := e.DeleteTombstone(ctx, t.Index); err != nil {
+		return "", err
+	}
+	return t.Index, nil
+}
@@ -3,8 +3,10 @@ package elastic
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"github.com/goccy/go-json"
 	"go-es/internal/errors"
+	"go-es/internal/esc"
 	"net/http"
 )
 
@@ -15,6 +17,19 @@ import (
 //
 // The payload should be the JSON representation of the mappings to be set.
 func (e *elastic) UpdateIndexMappings(ctx context.Context, index string, payload []byte) error {
+	// Same re-check as CreateIndex: payload is a typeless mapping body, which
+	// only 7.x+ accepts without an include_type_name override.
+	if e.version != nil {
+		if ok, err := e.version.AtLeast(esc.DefaultMinVersion); err == nil && !ok {
+			return errors.ElasticsearchError{
+				StatusCode: http.StatusPreconditionFailed,
+				Message:    "cluster version is below the minimum supported version",
+				Details:    fmt.Errorf("cluster version %s is below the minimum supported version %s", e.version.Number, esc.DefaultMinVersion),
+				Type:       errors.UnsupportedVersionError,
+			}
+		}
+	}
+
 	// Send update mappings request
 	res, err := e.client.Indices.PutMapping(
 		[]string{index},
@@ -22,12 +37,7 @@ func (e *elastic) UpdateIndexMappings(ctx context.Context, index string, payload
 		e.client.Indices.PutMapping.WithContext(ctx),
 	)
 	if err != nil {
-		return errors.ElasticsearchError{
-			StatusCode: http.StatusInternalServerError,
-			Message:    "failed to connect to elastic server",
-			Details:    err,
-			Type:       errors.ConnectionError,
-		}
+		return errors.NewConnectionError(err)
 	}
 	defer res.Body.Close()
 
@@ -73,12 +83,7 @@ func (e *elastic) UpdateAlias(ctx context.Context, alias, newIndex string) error
 		e.client.Indices.UpdateAliases.WithContext(ctx),
 	)
 	if err != nil {
-		return errors.ElasticsearchError{
-			StatusCode: http.StatusInternalServerError,
-			Message:    "failed to connect to elastic server",
-			Details:    err,
-			Type:       errors.ConnectionError,
-		}
+		return errors.NewConnectionError(err)
 	}
 	defer res.Body.Close()
 
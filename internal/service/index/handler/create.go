@@ -36,7 +36,7 @@ func CreateIndex(client *elasticsearch.Client) func(c *gin.Context) {
 		}
 
 		// Generate properties for the index
-		properties, err := generateProperties(req.Fields)
+		properties, analysis, autocompleteMeta, err := generateProperties(req.Fields)
 		if err != nil {
 			logs.Error().Err(err).Msg("failed to generate fields")
 			response.SendErrorResponse(c, response.ErrResponse{
@@ -51,32 +51,13 @@ func CreateIndex(client *elasticsearch.Client) func(c *gin.Context) {
 		// Build Elasticsearch settings and mappings
 		indexBody := map[string]interface{}{
 			"settings": map[string]interface{}{
-				"analysis": map[string]interface{}{
-					"analyzer": map[string]interface{}{
-						"autocomplete_analyzer": map[string]interface{}{ //  FIX: Change tokenizer
-							"tokenizer": "edge_ngram", // Use "edge_ngram" for prefix-based suggestions
-							"filter": []string{
-								"lowercase",
-							},
-						},
-						"standard_analyzer": map[string]interface{}{
-							"tokenizer": "standard",
-							"filter": []string{
-								"lowercase",
-							},
-						},
-					},
-					"filter": map[string]interface{}{
-						"autocomplete_filter": map[string]interface{}{
-							"type":     "edge_ngram", //  Ensures prefix search works
-							"min_gram": 2,
-							"max_gram": 20,
-						},
-					},
-				},
+				"analysis": analysis,
 			},
 			"mappings": map[string]interface{}{
 				"properties": properties,
+				"_meta": map[string]interface{}{
+					"autocomplete_modes": autocompleteMeta,
+				},
 			},
 			"aliases": map[string]interface{}{
 				req.Name: map[string]interface{}{},
@@ -166,53 +147,159 @@ func CreateIndex(client *elasticsearch.Client) func(c *gin.Context) {
 	}
 }
 
-// generateProperties generates the properties mapping for the given field configurations
-// and validates the results. It returns an error if any of the fields have invalid
-// configurations.
-func generateProperties(fields map[string]model.FieldConfig) (map[string]interface{}, error) {
+// generateProperties generates the properties mapping for the given field
+// configurations, merging in whatever each field's AutocompleteMode's
+// mappingSuggestStrategy needs. Besides the properties map, it returns the
+// union of every used strategy's analysis settings (for
+// "settings.analysis") and a fieldName -> mode map (for "mappings._meta"),
+// so AutoComplete callers can look up how a field was indexed. No field
+// configuration is rejected here, so the returned error is always nil.
+func generateProperties(fields map[string]model.FieldConfig) (map[string]interface{}, map[string]interface{}, map[string]string, error) {
 	properties := make(map[string]interface{})
-	// Iterate over the fields and generate their mappings
+	analysis := map[string]interface{}{}
+	autocompleteMeta := map[string]string{}
 
 	for fieldName, fieldConfig := range fields {
 		fieldMapping := map[string]interface{}{
 			"type": fieldConfig.Type,
 		}
 
+		// Nested overrides the object/nested distinction regardless of what
+		// Type was set to.
+		if fieldConfig.Nested {
+			fieldMapping["type"] = "nested"
+		}
+
+		// A join field's type and relations come entirely from Join.
+		if fieldConfig.Join != nil {
+			fieldMapping["type"] = "join"
+			fieldMapping["relations"] = fieldConfig.Join.Relations
+		}
+
+		// A "percolator" field needs no extra mapping attributes beyond its
+		// type; it's passed through like any other Type value so a saved
+		// query document (see the percolator sub-service) can be indexed
+		// under it.
+		if fieldConfig.Type == "percolator" {
+			fieldMapping["type"] = "percolator"
+		}
+
+		if len(fieldConfig.CopyTo) > 0 {
+			fieldMapping["copy_to"] = fieldConfig.CopyTo
+		}
+		if fieldConfig.Normalizer != "" {
+			fieldMapping["normalizer"] = fieldConfig.Normalizer
+		}
+		if fieldConfig.NullValue != nil {
+			fieldMapping["null_value"] = fieldConfig.NullValue
+		}
+		if fieldConfig.IgnoreAbove > 0 {
+			fieldMapping["ignore_above"] = fieldConfig.IgnoreAbove
+		}
+
+		multiFields := map[string]interface{}{}
+
 		// Handle autocomplete & search fields (Only for `text`)
-		if fieldConfig.Type == "text" && (fieldConfig.Autocomplete || fieldConfig.Search) {
-			fieldMapping["fields"] = map[string]interface{}{
-				"raw": map[string]interface{}{
-					"type": "keyword",
-				},
+		if fieldConfig.Type == "text" && (fieldConfig.AutocompleteMode != model.AutocompleteNone || fieldConfig.Search) {
+			multiFields["raw"] = map[string]interface{}{
+				"type": "keyword",
 			}
 
-			// Add proper `completion` field for suggestions
-			if fieldConfig.Autocomplete {
-				fieldMapping["fields"].(map[string]interface{})["suggest"] = map[string]interface{}{
-					"type": "completion",
-				}
+			if strategy := suggestStrategyFor(fieldConfig.AutocompleteMode); strategy != nil {
+				strategy.ApplyMapping(fieldMapping)
+				mergeAnalysisSettings(analysis, strategy.Settings())
+				autocompleteMeta[fieldName] = string(fieldConfig.AutocompleteMode)
 			}
 
 			// Add full-text search field
 			if fieldConfig.Search {
-				fieldMapping["fields"].(map[string]interface{})["fulltext"] = map[string]interface{}{
+				multiFields["fulltext"] = map[string]interface{}{
 					"type":     "text",
 					"analyzer": "standard_analyzer",
 				}
 			}
 		}
 
-		// Handle nested fields
-		if fieldConfig.Type == "nested" && len(fieldConfig.Properties) > 0 {
-			nestedProperties, err := generateProperties(fieldConfig.Properties)
+		// Explicit multi-fields, e.g. a "keyword" sub-field on a "text"
+		// field so both "title" and "title.keyword" are queryable.
+		for subName, subConfig := range fieldConfig.Fields {
+			multiFields[subName] = buildMultiFieldMapping(subConfig)
+		}
+
+		// Keep sub-fields a suggest strategy already added (e.g. the
+		// completion strategy's "suggest") alongside the ones built here.
+		if applied, ok := fieldMapping["fields"].(map[string]interface{}); ok {
+			for subName, sub := range applied {
+				multiFields[subName] = sub
+			}
+		}
+		if len(multiFields) > 0 {
+			fieldMapping["fields"] = multiFields
+		}
+
+		// Handle nested/object fields with sub-properties
+		if (fieldConfig.Type == "nested" || fieldConfig.Type == "object" || fieldConfig.Nested) && len(fieldConfig.Properties) > 0 {
+			nestedProperties, nestedAnalysis, nestedMeta, err := generateProperties(fieldConfig.Properties)
 			if err != nil {
-				return nil, err
+				return nil, nil, nil, err
 			}
 			fieldMapping["properties"] = nestedProperties
+			mergeAnalysisSettings(analysis, nestedAnalysis)
+			for nestedField, mode := range nestedMeta {
+				autocompleteMeta[fieldName+"."+nestedField] = mode
+			}
 		}
 
 		properties[fieldName] = fieldMapping
 	}
 
-	return properties, nil
+	return properties, analysis, autocompleteMeta, nil
+}
+
+// buildMultiFieldMapping builds the mapping for a single multi-field entry
+// under a parent field's "fields" section. Multi-fields are a flat
+// alternate indexing of the same value, so only the settings Elasticsearch
+// actually allows there are honored (no nested properties or further
+// multi-fields).
+func buildMultiFieldMapping(cfg model.FieldConfig) map[string]interface{} {
+	mapping := map[string]interface{}{
+		"type": cfg.Type,
+	}
+	if cfg.Analyzer != "" {
+		mapping["analyzer"] = cfg.Analyzer
+	}
+	if cfg.SearchAnalyzer != "" {
+		mapping["search_analyzer"] = cfg.SearchAnalyzer
+	}
+	if cfg.Normalizer != "" {
+		mapping["normalizer"] = cfg.Normalizer
+	}
+	if cfg.NullValue != nil {
+		mapping["null_value"] = cfg.NullValue
+	}
+	if cfg.IgnoreAbove > 0 {
+		mapping["ignore_above"] = cfg.IgnoreAbove
+	}
+	return mapping
+}
+
+// mergeAnalysisSettings folds src's "analyzer"/"filter" blocks into dst,
+// favoring whichever definition was already present on conflict.
+func mergeAnalysisSettings(dst, src map[string]interface{}) {
+	for _, section := range []string{"analyzer", "filter"} {
+		srcSection, ok := src[section].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		dstSection, ok := dst[section].(map[string]interface{})
+		if !ok {
+			dstSection = map[string]interface{}{}
+			dst[section] = dstSection
+		}
+		for name, def := range srcSection {
+			if _, exists := dstSection[name]; !exists {
+				dstSection[name] = def
+			}
+		}
+	}
 }
@@ -9,17 +9,18 @@ import (
 func TestGenerateProperties(t *testing.T) {
 	fields := map[string]model.FieldConfig{
 		"name": {
-			Type:         "text",
-			Autocomplete: true,
-			Search:       true,
+			Type:             "text",
+			AutocompleteMode: model.AutocompleteCompletion,
+			Search:           true,
 		},
 		"age": {
 			Type: "integer",
 		},
 	}
 
-	properties, err := generateProperties(fields)
+	properties, _, autocompleteMeta, err := generateProperties(fields)
 	assert.NoError(t, err)
 	assert.Equal(t, "text", properties["name"].(map[string]interface{})["type"])
 	assert.Equal(t, "integer", properties["age"].(map[string]interface{})["type"])
+	assert.Equal(t, "completion", autocompleteMeta["name"])
 }
@@ -4,17 +4,36 @@ import (
 	"errors"
 	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
 	cErr "go-es/internal/errors"
 	"go-es/internal/response"
 	"go-es/internal/service/index/elastic"
 	"go-es/logger"
 	"net/http"
+	"strconv"
 	"time"
 )
 
+// defaultTombstoneGracePeriod is how long a soft-deleted index survives
+// before the background sweeper physically deletes it, when the caller
+// doesn't supply ?grace_period_seconds=.
+const defaultTombstoneGracePeriod = 24 * time.Hour
+
+// deleteIndexResponse is returned by DeleteIndex's soft-delete path so
+// callers know which physical index was tombstoned and when the sweeper
+// will delete it; RestoreIndex needs only the alias to undo it.
+type deleteIndexResponse struct {
+	Index    string    `json:"index"`
+	DeleteAt time.Time `json:"delete_at"`
+}
+
 // DeleteIndex is a gin handler for deleting an index by its alias.
-// It will resolve the alias to the actual index name and then delete the index.
-// If the request fails, it will return an appropriate error response.
+//
+// By default it soft-deletes: the alias is detached from the physical
+// index and a tombstone is recorded with a grace period (?grace_period_seconds=,
+// default 24h) before the background sweeper (see server.NewServer) drops
+// the index for real. ?force=true skips the tombstone and deletes the
+// index immediately, as the endpoint always used to.
 func DeleteIndex(e *elasticsearch.Client) func(*gin.Context) {
 	return func(c *gin.Context) {
 		logs := logger.GetLogger(c)
@@ -58,8 +77,48 @@ func DeleteIndex(e *elasticsearch.Client) func(*gin.Context) {
 			return
 		}
 
-		// Delete the index
-		if err := ec.DeleteIndex(c.Request.Context(), indexName); err != nil {
+		force, _ := strconv.ParseBool(c.Query("force"))
+		if force {
+			deleteIndexHard(c, ec, indexName, logs)
+			return
+		}
+
+		if err := ec.RemoveAlias(c.Request.Context(), alias, indexName); err != nil {
+			var esErr cErr.ElasticsearchError
+			if errors.As(err, &esErr) {
+				logs.Error().Err(esErr.Details).Msg(esErr.Message)
+				response.SendErrorResponse(c, response.ErrResponse{
+					Code:    esErr.StatusCode,
+					Message: esErr.Message,
+					Details: esErr.Details.Error(),
+					Type:    esErr.Type.String(),
+				})
+				return
+			}
+
+			logs.Error().Err(err).Msg("failed to remove alias")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusInternalServerError,
+				Message: "failed to remove alias",
+				Details: err.Error(),
+				Type:    cErr.ServerError.String(),
+			})
+			return
+		}
+
+		grace := defaultTombstoneGracePeriod
+		if seconds, err := strconv.Atoi(c.Query("grace_period_seconds")); err == nil && seconds > 0 {
+			grace = time.Duration(seconds) * time.Second
+		}
+		now := time.Now()
+		deleteAt := now.Add(grace)
+
+		if err := ec.WriteTombstone(c.Request.Context(), elastic.Tombstone{
+			Alias:        alias,
+			Index:        indexName,
+			TombstonedAt: now,
+			DeleteAt:     deleteAt,
+		}); err != nil {
 			var esErr cErr.ElasticsearchError
 			if errors.As(err, &esErr) {
 				logs.Error().Err(esErr.Details).Msg(esErr.Message)
@@ -72,21 +131,56 @@ func DeleteIndex(e *elasticsearch.Client) func(*gin.Context) {
 				return
 			}
 
-			logs.Error().Err(err).Msg("failed to delete index")
+			logs.Error().Err(err).Msg("failed to write tombstone")
 			response.SendErrorResponse(c, response.ErrResponse{
 				Code:    http.StatusInternalServerError,
-				Message: "failed to delete index",
+				Message: "failed to write tombstone",
 				Details: err.Error(),
 				Type:    cErr.ServerError.String(),
 			})
 			return
 		}
 
-		c.JSON(http.StatusOK, response.SuccessResponse[any]{
-			Ts:      time.Now(),
-			Code:    http.StatusOK,
-			Message: "alias deleted successfully",
+		logs.Info().Str("alias", alias).Str("index", indexName).Time("delete_at", deleteAt).Msg("alias detached, index tombstoned")
+		c.JSON(http.StatusAccepted, response.SuccessResponse[deleteIndexResponse]{
+			Ts:      now,
+			Code:    http.StatusAccepted,
+			Message: "alias detached, index scheduled for deletion",
+			Data:    deleteIndexResponse{Index: indexName, DeleteAt: deleteAt},
+		})
+	}
+}
+
+// deleteIndexHard drops indexName immediately, bypassing the tombstone
+// grace period entirely, for DeleteIndex's ?force=true path.
+func deleteIndexHard(c *gin.Context, ec elastic.Elastic, indexName string, logs zerolog.Logger) {
+	if err := ec.DeleteIndex(c.Request.Context(), indexName); err != nil {
+		var esErr cErr.ElasticsearchError
+		if errors.As(err, &esErr) {
+			logs.Error().Err(esErr.Details).Msg(esErr.Message)
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    esErr.StatusCode,
+				Message: esErr.Message,
+				Details: esErr.Details.Error(),
+				Type:    esErr.Type.String(),
+			})
+			return
+		}
+
+		logs.Error().Err(err).Msg("failed to delete index")
+		response.SendErrorResponse(c, response.ErrResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "failed to delete index",
+			Details: err.Error(),
+			Type:    cErr.ServerError.String(),
 		})
 		return
 	}
+
+	logs.Info().Str("index", indexName).Msg("index deleted")
+	c.JSON(http.StatusOK, response.SuccessResponse[any]{
+		Ts:      time.Now(),
+		Code:    http.StatusOK,
+		Message: "index deleted successfully",
+	})
 }
@@ -2,13 +2,18 @@ package handler
 
 import (
 	"errors"
+	"fmt"
 	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/gin-gonic/gin"
 	cErr "go-es/internal/errors"
+	"go-es/internal/esc"
 	"go-es/internal/response"
 	"go-es/internal/service/index/elastic"
 	"go-es/logger"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -55,3 +60,159 @@ func ListIndices(e *elasticsearch.Client) func(*gin.Context) {
 		return
 	}
 }
+
+// GetClusterVersion reports the Elasticsearch cluster version, so operators
+// can confirm what the client connected to without shelling into the
+// cluster directly. It returns the version cached at startup by
+// NewClientWithVersionCheck, falling back to a fresh `GET /` probe if none
+// was cached (e.g. the client was built with the plain NewClient).
+func GetClusterVersion(e *elasticsearch.Client) func(*gin.Context) {
+	return func(c *gin.Context) {
+		logs := logger.GetLogger(c)
+
+		version := esc.DetectedVersion()
+		if version != nil {
+			c.JSON(http.StatusOK, response.SuccessResponse[esc.ClusterVersion]{
+				Ts:      time.Now(),
+				Code:    http.StatusOK,
+				Message: "success",
+				Data:    *version,
+			})
+			return
+		}
+
+		version, err := esc.DetectVersion(e)
+		if err != nil {
+			var esErr cErr.ElasticsearchError
+			if errors.As(err, &esErr) {
+				logs.Error().Err(esErr.Details).Msg(esErr.Message)
+				response.SendErrorResponse(c, response.ErrResponse{
+					Code:    esErr.StatusCode,
+					Message: esErr.Message,
+					Details: esErr.Details.Error(),
+					Type:    esErr.Type.String(),
+				})
+				return
+			}
+			logs.Error().Err(err).Msg("failed to detect cluster version")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusInternalServerError,
+				Message: "failed to detect cluster version",
+				Details: err.Error(),
+				Type:    cErr.ServerError.String(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, response.SuccessResponse[esc.ClusterVersion]{
+			Ts:      time.Now(),
+			Code:    http.StatusOK,
+			Message: "success",
+			Data:    *version,
+		})
+	}
+}
+
+// GetClusterNodes reports the cluster nodes currently known to be reachable,
+// as tracked by the background sniffing/health-check loop started when
+// esc.Config.HealthcheckInterval or esc.Config.DiscoverNodesOnStart is set.
+// It returns an empty list (not an error) if node discovery was never
+// enabled, since that's indistinguishable from "no nodes have been found
+// reachable yet" without threading more state through.
+func GetClusterNodes() func(*gin.Context) {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, response.SuccessResponse[[]string]{
+			Ts:      time.Now(),
+			Code:    http.StatusOK,
+			Message: "success",
+			Data:    esc.LiveNodes(),
+		})
+	}
+}
+
+// Metrics exposes the retrying transport's counters in Prometheus text
+// exposition format: cumulative attempts/retries/breaker-opens, the last
+// response status seen, and each node's last observed latency and breaker
+// state. It reports an empty body if retries are disabled (no RetryPolicy
+// configured), since no retrytransport.RoundTripper is wired in that case.
+func Metrics() func(*gin.Context) {
+	return func(c *gin.Context) {
+		c.Header("Content-Type", "text/plain; version=0.0.4")
+
+		rt := esc.Transport()
+		if rt == nil {
+			c.Status(http.StatusOK)
+			return
+		}
+
+		m := rt.Metrics()
+		var b strings.Builder
+		fmt.Fprintf(&b, "# HELP es_transport_attempts_total Total number of Elasticsearch requests attempted, including retries.\n")
+		fmt.Fprintf(&b, "# TYPE es_transport_attempts_total counter\n")
+		fmt.Fprintf(&b, "es_transport_attempts_total %d\n", m.Attempts)
+		fmt.Fprintf(&b, "# HELP es_transport_retries_total Total number of retry attempts issued by the transport.\n")
+		fmt.Fprintf(&b, "# TYPE es_transport_retries_total counter\n")
+		fmt.Fprintf(&b, "es_transport_retries_total %d\n", m.Retries)
+		fmt.Fprintf(&b, "# HELP es_transport_breaker_opens_total Total number of times a per-node circuit breaker has tripped.\n")
+		fmt.Fprintf(&b, "# TYPE es_transport_breaker_opens_total counter\n")
+		fmt.Fprintf(&b, "es_transport_breaker_opens_total %d\n", m.BreakerOpens)
+		fmt.Fprintf(&b, "# HELP es_transport_last_status The HTTP status code of the most recently completed request.\n")
+		fmt.Fprintf(&b, "# TYPE es_transport_last_status gauge\n")
+		fmt.Fprintf(&b, "es_transport_last_status %d\n", m.LastStatus)
+
+		hosts := make([]string, 0, len(m.Nodes))
+		for host := range m.Nodes {
+			hosts = append(hosts, host)
+		}
+		sort.Strings(hosts)
+
+		fmt.Fprintf(&b, "# HELP es_transport_node_latency_milliseconds Latency of the most recent attempt against a node.\n")
+		fmt.Fprintf(&b, "# TYPE es_transport_node_latency_milliseconds gauge\n")
+		for _, host := range hosts {
+			fmt.Fprintf(&b, "es_transport_node_latency_milliseconds{node=%q} %d\n", host, m.Nodes[host].LastLatencyMillis)
+		}
+		fmt.Fprintf(&b, "# HELP es_transport_node_breaker_open Whether a node's circuit breaker is currently open (1) or closed (0).\n")
+		fmt.Fprintf(&b, "# TYPE es_transport_node_breaker_open gauge\n")
+		for _, host := range hosts {
+			open := 0
+			if m.Nodes[host].BreakerOpen {
+				open = 1
+			}
+			fmt.Fprintf(&b, "es_transport_node_breaker_open{node=%q} %d\n", host, open)
+		}
+
+		fmt.Fprintf(&b, "# HELP es_requests_total Total number of Elasticsearch requests, labeled by operation and final HTTP status.\n")
+		fmt.Fprintf(&b, "# TYPE es_requests_total counter\n")
+		sort.Slice(m.RequestsByOpStatus, func(i, j int) bool {
+			if m.RequestsByOpStatus[i].Op != m.RequestsByOpStatus[j].Op {
+				return m.RequestsByOpStatus[i].Op < m.RequestsByOpStatus[j].Op
+			}
+			return m.RequestsByOpStatus[i].Status < m.RequestsByOpStatus[j].Status
+		})
+		for _, rs := range m.RequestsByOpStatus {
+			fmt.Fprintf(&b, "es_requests_total{op=%q,status=%q} %d\n", rs.Op, strconv.Itoa(rs.Status), rs.Count)
+		}
+
+		fmt.Fprintf(&b, "# HELP es_retries_total Total number of retry attempts issued, labeled by operation.\n")
+		fmt.Fprintf(&b, "# TYPE es_retries_total counter\n")
+		ops := make([]string, 0, len(m.RetriesByOp))
+		for op := range m.RetriesByOp {
+			ops = append(ops, op)
+		}
+		sort.Strings(ops)
+		for _, op := range ops {
+			fmt.Fprintf(&b, "es_retries_total{op=%q} %d\n", op, m.RetriesByOp[op])
+		}
+
+		fmt.Fprintf(&b, "# HELP es_request_duration_seconds Wall time of a complete Elasticsearch request, including retries.\n")
+		fmt.Fprintf(&b, "# TYPE es_request_duration_seconds histogram\n")
+		for _, bucket := range m.DurationBuckets {
+			fmt.Fprintf(&b, "es_request_duration_seconds_bucket{le=%q} %d\n", strconv.FormatFloat(bucket.LE, 'g', -1, 64), bucket.Count)
+		}
+		fmt.Fprintf(&b, "es_request_duration_seconds_bucket{le=\"+Inf\"} %d\n", m.DurationCount)
+		fmt.Fprintf(&b, "es_request_duration_seconds_sum %g\n", m.DurationSumSeconds)
+		fmt.Fprintf(&b, "es_request_duration_seconds_count %d\n", m.DurationCount)
+
+		c.String(http.StatusOK, b.String())
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"go-es/internal/service/index/elastic"
 	"go-es/internal/service/index/model"
+	middlewares "go-es/server/middleware"
 )
 
 // GetIndex retrieves details of a single index in a structured format.
@@ -37,6 +38,11 @@ func GetIndex(e *elasticsearch.Client) func(*gin.Context) {
 			return
 		}
 
+		// Restrict access to tenants/roles listed in the caller's "indices" claim
+		if !middlewares.AuthorizeIndex(c, alias) {
+			return
+		}
+
 		// Initialize elastic client
 		ec := elastic.New(e)
 
@@ -0,0 +1,498 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/gin-gonic/gin"
+	"github.com/goccy/go-json"
+	cErr "go-es/internal/errors"
+	"go-es/internal/response"
+	"go-es/internal/service/index/elastic"
+	"go-es/internal/service/index/model/request"
+	"go-es/logger"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// reindexStartResponse is returned by StartReindex so callers can poll
+// GetTaskStatus and later call SwapReindexAlias.
+type reindexStartResponse struct {
+	TaskID   string `json:"task_id"`
+	OldIndex string `json:"old_index"`
+	NewIndex string `json:"new_index"`
+}
+
+// StartReindex begins a zero-downtime migration of alias to a new physical
+// index built from the mappings in the request body: it creates the
+// destination index and submits an asynchronous `_reindex` task, returning
+// the task ID so progress can be polled via GetTaskStatus and the alias
+// swapped via SwapReindexAlias once it completes.
+func StartReindex(e *elasticsearch.Client) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		logs := logger.GetLogger(c)
+
+		alias := c.Param("alias")
+		if alias == "" {
+			logs.Error().Msg("alias name is required in URI")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusBadRequest,
+				Message: "alias name is required in URI",
+				Details: "alias name is required in URI",
+				Type:    cErr.BadRequestError.String(),
+			})
+			return
+		}
+
+		var req request.ReindexRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			logs.Error().Err(err).Msg("invalid request payload")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusBadRequest,
+				Message: "invalid request payload",
+				Details: err.Error(),
+				Type:    cErr.BadRequestError.String(),
+			})
+			return
+		}
+
+		properties, analysis, autocompleteMeta, err := generateProperties(req.Fields)
+		if err != nil {
+			logs.Error().Err(err).Msg("failed to generate fields")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusBadRequest,
+				Message: "failed to generate fields",
+				Details: err.Error(),
+				Type:    cErr.BadRequestError.String(),
+			})
+			return
+		}
+
+		mapping, err := json.Marshal(map[string]interface{}{
+			"settings": map[string]interface{}{"analysis": analysis},
+			"mappings": map[string]interface{}{
+				"properties": properties,
+				"_meta": map[string]interface{}{
+					"autocomplete_modes": autocompleteMeta,
+				},
+			},
+		})
+		if err != nil {
+			logs.Error().Err(err).Msg("failed to marshal mapping")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusBadRequest,
+				Message: "failed to marshal mapping",
+				Details: err.Error(),
+				Type:    cErr.BadRequestError.String(),
+			})
+			return
+		}
+
+		ec := elastic.New(e)
+
+		oldIndex, err := ec.ResolveAlias(c.Request.Context(), alias)
+		if err != nil {
+			var esErr cErr.ElasticsearchError
+			if errors.As(err, &esErr) {
+				logs.Error().Err(esErr.Details).Msg(esErr.Message)
+				response.SendErrorResponse(c, response.ErrResponse{
+					Code:    esErr.StatusCode,
+					Message: esErr.Message,
+					Details: esErr.Details.Error(),
+					Type:    esErr.Type.String(),
+				})
+				return
+			}
+			logs.Error().Err(err).Msg("failed to resolve alias")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusInternalServerError,
+				Message: "failed to resolve alias",
+				Details: err.Error(),
+				Type:    cErr.ServerError.String(),
+			})
+			return
+		}
+
+		newIndex := fmt.Sprintf("%s_%s", alias, time.Now().Format("20060102150405"))
+
+		taskID, err := ec.StartReindex(c.Request.Context(), oldIndex, newIndex, mapping, elastic.ReindexOptions{
+			Conflicts:         req.Conflicts,
+			Refresh:           req.Refresh,
+			RequestsPerSecond: req.RequestsPerSecond,
+			Slices:            req.Slices,
+			Script:            req.Script,
+		})
+		if err != nil {
+			var esErr cErr.ElasticsearchError
+			if errors.As(err, &esErr) {
+				logs.Error().Err(esErr.Details).Msg(esErr.Message)
+				response.SendErrorResponse(c, response.ErrResponse{
+					Code:    esErr.StatusCode,
+					Message: esErr.Message,
+					Details: esErr.Details.Error(),
+					Type:    esErr.Type.String(),
+				})
+				return
+			}
+			logs.Error().Err(err).Msg("failed to start reindex")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusInternalServerError,
+				Message: "failed to start reindex",
+				Details: err.Error(),
+				Type:    cErr.ServerError.String(),
+			})
+			return
+		}
+
+		logs.Info().Str("task_id", taskID).Str("old_index", oldIndex).Str("new_index", newIndex).Msg("reindex started")
+		c.JSON(http.StatusAccepted, response.SuccessResponse[reindexStartResponse]{
+			Ts:      time.Now(),
+			Code:    http.StatusAccepted,
+			Message: "reindex started",
+			Data: reindexStartResponse{
+				TaskID:   taskID,
+				OldIndex: oldIndex,
+				NewIndex: newIndex,
+			},
+		})
+	}
+}
+
+// SwapReindexAlias atomically moves alias from old_index to new_index
+// (passed as query parameters) once the reindex task is reported complete,
+// optionally deleting the old physical index when ?delete_old=true, after
+// waiting ?grace_period_seconds= first. If ?failure_threshold= is set and
+// the task reports more failures than that, the swap is refused in favor of
+// RollbackReindex.
+func SwapReindexAlias(e *elasticsearch.Client) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		logs := logger.GetLogger(c)
+
+		alias := c.Param("alias")
+		taskID := c.Param("taskID")
+		oldIndex := c.Query("old_index")
+		newIndex := c.Query("new_index")
+		if alias == "" || taskID == "" || oldIndex == "" || newIndex == "" {
+			logs.Error().Msg("alias, taskID, old_index and new_index are required")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusBadRequest,
+				Message: "alias, taskID, old_index and new_index are required",
+				Details: "alias, taskID, old_index and new_index are required",
+				Type:    cErr.BadRequestError.String(),
+			})
+			return
+		}
+
+		ec := elastic.New(e)
+
+		status, err := ec.GetReindexStatus(c.Request.Context(), taskID)
+		if err != nil {
+			var esErr cErr.ElasticsearchError
+			if errors.As(err, &esErr) {
+				logs.Error().Err(esErr.Details).Msg(esErr.Message)
+				response.SendErrorResponse(c, response.ErrResponse{
+					Code:    esErr.StatusCode,
+					Message: esErr.Message,
+					Details: esErr.Details.Error(),
+					Type:    esErr.Type.String(),
+				})
+				return
+			}
+			logs.Error().Err(err).Msg("failed to get reindex status")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusInternalServerError,
+				Message: "failed to get reindex status",
+				Details: err.Error(),
+				Type:    cErr.ServerError.String(),
+			})
+			return
+		}
+
+		if !status.Completed {
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusConflict,
+				Message: "reindex task has not completed yet",
+				Details: "reindex task has not completed yet",
+				Type:    cErr.BadRequestError.String(),
+			})
+			return
+		}
+
+		if threshold, err := strconv.ParseInt(c.Query("failure_threshold"), 10, 64); err == nil && threshold > 0 && status.Failures > threshold {
+			logs.Warn().Int64("failures", status.Failures).Int64("threshold", threshold).Msg("reindex exceeded failure threshold, refusing to swap")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusConflict,
+				Message: "reindex task exceeded failure threshold, call rollback instead of swap",
+				Details: fmt.Sprintf("%d failures exceeds threshold %d", status.Failures, threshold),
+				Type:    cErr.BadRequestError.String(),
+			})
+			return
+		}
+
+		if err := ec.SwapAlias(c.Request.Context(), alias, oldIndex, newIndex); err != nil {
+			var esErr cErr.ElasticsearchError
+			if errors.As(err, &esErr) {
+				logs.Error().Err(esErr.Details).Msg(esErr.Message)
+				response.SendErrorResponse(c, response.ErrResponse{
+					Code:    esErr.StatusCode,
+					Message: esErr.Message,
+					Details: esErr.Details.Error(),
+					Type:    esErr.Type.String(),
+				})
+				return
+			}
+			logs.Error().Err(err).Msg("failed to swap alias")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusInternalServerError,
+				Message: "failed to swap alias",
+				Details: err.Error(),
+				Type:    cErr.ServerError.String(),
+			})
+			return
+		}
+
+		if c.Query("delete_old") == "true" {
+			graceSeconds, _ := strconv.Atoi(c.Query("grace_period_seconds"))
+			deleteOldIndex(ec, oldIndex, time.Duration(graceSeconds)*time.Second, logs)
+		}
+
+		logs.Info().Str("alias", alias).Str("old_index", oldIndex).Str("new_index", newIndex).Msg("alias swapped")
+		c.JSON(http.StatusOK, response.SuccessResponse[any]{
+			Ts:      time.Now(),
+			Code:    http.StatusOK,
+			Message: "alias swapped successfully",
+		})
+	}
+}
+
+// RollbackReindex cancels the reindex task (if still running) and deletes
+// its destination index, undoing a reindex that never had its alias
+// swapped — e.g. because SwapReindexAlias refused it for exceeding a
+// failure threshold.
+func RollbackReindex(e *elasticsearch.Client) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		logs := logger.GetLogger(c)
+
+		taskID := c.Param("taskID")
+		destIndex := c.Query("dest_index")
+		if taskID == "" || destIndex == "" {
+			logs.Error().Msg("taskID and dest_index are required")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusBadRequest,
+				Message: "taskID and dest_index are required",
+				Details: "taskID and dest_index are required",
+				Type:    cErr.BadRequestError.String(),
+			})
+			return
+		}
+
+		ec := elastic.New(e)
+		if err := ec.RollbackReindex(c.Request.Context(), taskID, destIndex); err != nil {
+			var esErr cErr.ElasticsearchError
+			if errors.As(err, &esErr) {
+				logs.Error().Err(esErr.Details).Msg(esErr.Message)
+				response.SendErrorResponse(c, response.ErrResponse{
+					Code:    esErr.StatusCode,
+					Message: esErr.Message,
+					Details: esErr.Details.Error(),
+					Type:    esErr.Type.String(),
+				})
+				return
+			}
+			logs.Error().Err(err).Msg("failed to roll back reindex")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusInternalServerError,
+				Message: "failed to roll back reindex",
+				Details: err.Error(),
+				Type:    cErr.ServerError.String(),
+			})
+			return
+		}
+
+		logs.Info().Str("task_id", taskID).Str("dest_index", destIndex).Msg("reindex rolled back")
+		c.JSON(http.StatusOK, response.SuccessResponse[any]{
+			Ts:      time.Now(),
+			Code:    http.StatusOK,
+			Message: "reindex rolled back successfully",
+		})
+	}
+}
+
+// GetReindexStatusByAlias reports the progress of the background reindex job
+// (if any) that UpdateIndex started for alias, by combining the in-flight
+// job tracked in the registry with a fresh `_tasks/{id}` poll.
+func GetReindexStatusByAlias(e *elasticsearch.Client) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		logs := logger.GetLogger(c)
+
+		alias := c.Param("alias")
+		if alias == "" {
+			logs.Error().Msg("alias name is required in URI")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusBadRequest,
+				Message: "alias name is required in URI",
+				Details: "alias name is required in URI",
+				Type:    cErr.BadRequestError.String(),
+			})
+			return
+		}
+
+		job, ok := defaultReindexRegistry.get(alias)
+		if !ok {
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusNotFound,
+				Message: "no reindex job in progress for alias",
+				Details: "no reindex job in progress for alias",
+				Type:    cErr.NotFoundError.String(),
+			})
+			return
+		}
+
+		ec := elastic.New(e)
+		status, err := ec.GetReindexStatus(c.Request.Context(), job.TaskID)
+		if err != nil {
+			var esErr cErr.ElasticsearchError
+			if errors.As(err, &esErr) {
+				logs.Error().Err(esErr.Details).Msg(esErr.Message)
+				response.SendErrorResponse(c, response.ErrResponse{
+					Code:    esErr.StatusCode,
+					Message: esErr.Message,
+					Details: esErr.Details.Error(),
+					Type:    esErr.Type.String(),
+				})
+				return
+			}
+			logs.Error().Err(err).Msg("failed to get reindex status")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusInternalServerError,
+				Message: "failed to get reindex status",
+				Details: err.Error(),
+				Type:    cErr.ServerError.String(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, response.SuccessResponse[reindexStatusResponse]{
+			Ts:      time.Now(),
+			Code:    http.StatusOK,
+			Message: "reindex status",
+			Data: reindexStatusResponse{
+				Job:    *job,
+				Status: *status,
+			},
+		})
+	}
+}
+
+// reindexStatusResponse pairs a tracked reindexJob with its latest polled
+// task status for GetReindexStatusByAlias.
+type reindexStatusResponse struct {
+	Job    reindexJob                `json:"job"`
+	Status elastic.ReindexTaskStatus `json:"status"`
+}
+
+// GetTaskStatus proxies GET `_tasks/:taskID` for any Elasticsearch task.
+// Every task-ID-keyed poll in this package routes through here, not just the
+// generic `/_tasks/:taskID`: StartReindex and Rollover callers land on this
+// same handler via `/indices/:alias/reindex/:taskID` and `/rollover/:taskID`
+// respectively, since a reindex task's status is a plain `_tasks/{id}` poll
+// with no reindex-specific shape to add.
+func GetTaskStatus(e *elasticsearch.Client) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		logs := logger.GetLogger(c)
+
+		taskID := c.Param("taskID")
+		if taskID == "" {
+			logs.Error().Msg("task ID is required in URI")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusBadRequest,
+				Message: "task ID is required in URI",
+				Details: "task ID is required in URI",
+				Type:    cErr.BadRequestError.String(),
+			})
+			return
+		}
+
+		ec := elastic.New(e)
+		status, err := ec.GetReindexStatus(c.Request.Context(), taskID)
+		if err != nil {
+			var esErr cErr.ElasticsearchError
+			if errors.As(err, &esErr) {
+				logs.Error().Err(esErr.Details).Msg(esErr.Message)
+				response.SendErrorResponse(c, response.ErrResponse{
+					Code:    esErr.StatusCode,
+					Message: esErr.Message,
+					Details: esErr.Details.Error(),
+					Type:    esErr.Type.String(),
+				})
+				return
+			}
+			logs.Error().Err(err).Msg("failed to get task status")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusInternalServerError,
+				Message: "failed to get task status",
+				Details: err.Error(),
+				Type:    cErr.ServerError.String(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, response.SuccessResponse[elastic.ReindexTaskStatus]{
+			Ts:      time.Now(),
+			Code:    http.StatusOK,
+			Message: "task status",
+			Data:    *status,
+		})
+	}
+}
+
+// CancelTask proxies POST `_tasks/:taskID/_cancel`, requesting cooperative
+// cancellation of a running task.
+func CancelTask(e *elasticsearch.Client) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		logs := logger.GetLogger(c)
+
+		taskID := c.Param("taskID")
+		if taskID == "" {
+			logs.Error().Msg("task ID is required in URI")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusBadRequest,
+				Message: "task ID is required in URI",
+				Details: "task ID is required in URI",
+				Type:    cErr.BadRequestError.String(),
+			})
+			return
+		}
+
+		ec := elastic.New(e)
+		if err := ec.CancelReindexTask(c.Request.Context(), taskID); err != nil {
+			var esErr cErr.ElasticsearchError
+			if errors.As(err, &esErr) {
+				logs.Error().Err(esErr.Details).Msg(esErr.Message)
+				response.SendErrorResponse(c, response.ErrResponse{
+					Code:    esErr.StatusCode,
+					Message: esErr.Message,
+					Details: esErr.Details.Error(),
+					Type:    esErr.Type.String(),
+				})
+				return
+			}
+			logs.Error().Err(err).Msg("failed to cancel task")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusInternalServerError,
+				Message: "failed to cancel task",
+				Details: err.Error(),
+				Type:    cErr.ServerError.String(),
+			})
+			return
+		}
+
+		logs.Info().Str("task_id", taskID).Msg("task cancellation requested")
+		c.JSON(http.StatusOK, response.SuccessResponse[any]{
+			Ts:      time.Now(),
+			Code:    http.StatusOK,
+			Message: "task cancellation requested",
+		})
+	}
+}
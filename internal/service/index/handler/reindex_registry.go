@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"context"
+	"go-es/internal/service/index/elastic"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// reindexPollInterval is how often the background worker polls the
+// Elasticsearch task status of an in-flight reindex job.
+const reindexPollInterval = 2 * time.Second
+
+// reindexJob tracks an asynchronous reindex started by UpdateIndex so a
+// background worker can swap the alias and drop the old physical index once
+// the underlying Elasticsearch task completes.
+type reindexJob struct {
+	Alias            string        `json:"alias"`
+	OldIndex         string        `json:"old_index"`
+	NewIndex         string        `json:"new_index"`
+	TaskID           string        `json:"task_id"`
+	StartedAt        time.Time     `json:"started_at"`
+	FailureThreshold int64         `json:"failure_threshold,omitempty"` // roll back instead of swapping once failures exceed this; 0 disables the check
+	GracePeriod      time.Duration `json:"grace_period,omitempty"`      // delay before deleting OldIndex after a successful swap
+}
+
+// reindexRegistry tracks in-flight reindex jobs keyed by alias.
+type reindexRegistry struct {
+	mu   sync.RWMutex
+	jobs map[string]*reindexJob
+}
+
+func newReindexRegistry() *reindexRegistry {
+	return &reindexRegistry{jobs: make(map[string]*reindexJob)}
+}
+
+// defaultReindexRegistry is the package-level registry consulted by
+// GetReindexStatusByAlias and populated by watchReindexJob.
+var defaultReindexRegistry = newReindexRegistry()
+
+func (r *reindexRegistry) start(job *reindexJob) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.jobs[job.Alias] = job
+}
+
+func (r *reindexRegistry) get(alias string) (*reindexJob, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	job, ok := r.jobs[alias]
+	return job, ok
+}
+
+func (r *reindexRegistry) finish(alias string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.jobs, alias)
+}
+
+// watchReindexJob polls job's task until Elasticsearch reports it complete,
+// then swaps the alias onto the new index and deletes the old one. It runs
+// in its own goroutine for the lifetime of the reindex and always removes
+// job from the registry before returning.
+func watchReindexJob(ec elastic.Elastic, job *reindexJob) {
+	logs := log.With().Str("component", "reindex_worker").Str("alias", job.Alias).Str("task_id", job.TaskID).Logger()
+	defer defaultReindexRegistry.finish(job.Alias)
+
+	ticker := time.NewTicker(reindexPollInterval)
+	defer ticker.Stop()
+
+	ctx := context.Background()
+	for range ticker.C {
+		status, err := ec.GetReindexStatus(ctx, job.TaskID)
+		if err != nil {
+			logs.Error().Err(err).Msg("failed to poll reindex task status")
+			return
+		}
+		if !status.Completed {
+			continue
+		}
+
+		if job.FailureThreshold > 0 && status.Failures > job.FailureThreshold {
+			logs.Warn().Int64("failures", status.Failures).Int64("threshold", job.FailureThreshold).
+				Msg("reindex exceeded failure threshold, rolling back")
+			if err := ec.RollbackReindex(ctx, job.TaskID, job.NewIndex); err != nil {
+				logs.Error().Err(err).Msg("failed to roll back reindex")
+			}
+			return
+		}
+
+		if err := ec.SwapAlias(ctx, job.Alias, job.OldIndex, job.NewIndex); err != nil {
+			logs.Error().Err(err).Msg("failed to swap alias after reindex")
+			return
+		}
+		deleteOldIndex(ec, job.OldIndex, job.GracePeriod, logs)
+		logs.Info().Msg("reindex completed, alias swapped")
+		return
+	}
+}
+
+// deleteOldIndex deletes oldIndex, waiting grace first if set, so any
+// in-flight requests routed to it before the alias swap propagated have time
+// to drain. It logs rather than returning an error since it runs detached
+// from the request/job that triggered it.
+func deleteOldIndex(ec elastic.Elastic, oldIndex string, grace time.Duration, logs zerolog.Logger) {
+	del := func() {
+		if err := ec.DeleteIndex(context.Background(), oldIndex); err != nil {
+			logs.Warn().Err(err).Str("index", oldIndex).Msg("alias swapped but failed to delete old index")
+		}
+	}
+	if grace <= 0 {
+		del()
+		return
+	}
+	go func() {
+		time.Sleep(grace)
+		del()
+	}()
+}
@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go-es/internal/service/index/elastic"
+)
+
+// fakeReindexElastic implements elastic.Elastic by embedding the interface
+// (so any method the test doesn't exercise panics if called, rather than
+// needing to be stubbed out) and overriding just the calls watchReindexJob
+// and deleteOldIndex make.
+type fakeReindexElastic struct {
+	elastic.Elastic
+
+	mu           sync.Mutex
+	statusCalls  int
+	status       *elastic.ReindexTaskStatus
+	swapped      bool
+	rolledBack   bool
+	deletedIndex string
+}
+
+func (f *fakeReindexElastic) GetReindexStatus(context.Context, string) (*elastic.ReindexTaskStatus, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.statusCalls++
+	return f.status, nil
+}
+
+func (f *fakeReindexElastic) SwapAlias(context.Context, string, string, string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.swapped = true
+	return nil
+}
+
+func (f *fakeReindexElastic) RollbackReindex(context.Context, string, string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.rolledBack = true
+	return nil
+}
+
+func (f *fakeReindexElastic) DeleteIndex(_ context.Context, index string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deletedIndex = index
+	return nil
+}
+
+// TestReindexRegistryConcurrentAccess drives reindexRegistry.start/get/finish
+// from many goroutines at once, the same way concurrent HTTP handlers
+// (Rollover, UpdateIndex, GetReindexStatusByAlias) and the background
+// watchReindexJob goroutine share defaultReindexRegistry.
+func TestReindexRegistryConcurrentAccess(t *testing.T) {
+	r := newReindexRegistry()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			job := &reindexJob{Alias: "products", TaskID: "task-1"}
+			r.start(job)
+			r.get("products")
+			if i%5 == 0 {
+				r.finish("products")
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+// TestWatchReindexJobSwapsAliasOnCompletion runs watchReindexJob end-to-end
+// against a fake Elastic that reports the task complete on its first poll,
+// and checks it swaps the alias, deletes the old index, and removes the job
+// from the registry before returning.
+func TestWatchReindexJobSwapsAliasOnCompletion(t *testing.T) {
+	fe := &fakeReindexElastic{status: &elastic.ReindexTaskStatus{Completed: true}}
+	job := &reindexJob{Alias: "products", OldIndex: "products-1", NewIndex: "products-2", TaskID: "task-1"}
+	defaultReindexRegistry.start(job)
+
+	done := make(chan struct{})
+	go func() {
+		watchReindexJob(fe, job)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("watchReindexJob did not return after the task completed")
+	}
+
+	assert.True(t, fe.swapped)
+	assert.Equal(t, "products-1", fe.deletedIndex)
+	_, ok := defaultReindexRegistry.get("products")
+	assert.False(t, ok, "watchReindexJob must remove the job from the registry once it's done")
+}
+
+// TestWatchReindexJobRollsBackOnFailureThreshold checks that when the task
+// completes with more failures than the job's threshold, watchReindexJob
+// rolls back instead of swapping the alias.
+func TestWatchReindexJobRollsBackOnFailureThreshold(t *testing.T) {
+	fe := &fakeReindexElastic{status: &elastic.ReindexTaskStatus{Completed: true, Failures: 10}}
+	job := &reindexJob{Alias: "orders", OldIndex: "orders-1", NewIndex: "orders-2", TaskID: "task-2", FailureThreshold: 1}
+	defaultReindexRegistry.start(job)
+
+	done := make(chan struct{})
+	go func() {
+		watchReindexJob(fe, job)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("watchReindexJob did not return after the task completed")
+	}
+
+	assert.True(t, fe.rolledBack)
+	assert.False(t, fe.swapped)
+}
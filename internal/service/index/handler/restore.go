@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"errors"
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/gin-gonic/gin"
+	cErr "go-es/internal/errors"
+	"go-es/internal/response"
+	"go-es/internal/service/index/elastic"
+	"go-es/logger"
+	"net/http"
+	"time"
+)
+
+// restoreIndexResponse reports which physical index a RestoreIndex call
+// re-attached alias to.
+type restoreIndexResponse struct {
+	Index string `json:"index"`
+}
+
+// RestoreIndex re-attaches alias to its most recently soft-deleted physical
+// index, undoing DeleteIndex before the tombstone's grace period expires
+// and the background sweeper drops it for real.
+func RestoreIndex(e *elasticsearch.Client) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		logs := logger.GetLogger(c)
+
+		alias := c.Param("alias")
+		if alias == "" {
+			logs.Error().Msg("alias name is required in URI")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusBadRequest,
+				Message: "alias name is required in URI",
+				Details: "alias name is required in URI",
+				Type:    cErr.BadRequestError.String(),
+			})
+			return
+		}
+
+		ec := elastic.New(e)
+		indexName, err := ec.RestoreAlias(c.Request.Context(), alias)
+		if err != nil {
+			var esErr cErr.ElasticsearchError
+			if errors.As(err, &esErr) {
+				logs.Error().Err(esErr.Details).Msg(esErr.Message)
+				response.SendErrorResponse(c, response.ErrResponse{
+					Code:    esErr.StatusCode,
+					Message: esErr.Message,
+					Details: esErr.Details.Error(),
+					Type:    esErr.Type.String(),
+				})
+				return
+			}
+
+			logs.Error().Err(err).Msg("failed to restore alias")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusInternalServerError,
+				Message: "failed to restore alias",
+				Details: err.Error(),
+				Type:    cErr.ServerError.String(),
+			})
+			return
+		}
+
+		logs.Info().Str("alias", alias).Str("index", indexName).Msg("alias restored from tombstone")
+		c.JSON(http.StatusOK, response.SuccessResponse[restoreIndexResponse]{
+			Ts:      time.Now(),
+			Code:    http.StatusOK,
+			Message: "alias restored",
+			Data:    restoreIndexResponse{Index: indexName},
+		})
+	}
+}
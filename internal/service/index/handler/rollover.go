@@ -0,0 +1,235 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/gin-gonic/gin"
+	"github.com/goccy/go-json"
+	cErr "go-es/internal/errors"
+	"go-es/internal/response"
+	"go-es/internal/service/index/elastic"
+	"go-es/internal/service/index/model/request"
+	"go-es/logger"
+	"net/http"
+	"time"
+)
+
+// Rollover unconditionally migrates alias onto a brand-new `<alias>-<timestamp>`
+// physical index built from the mappings in the request body, then tracks the
+// migration through the same background watcher UpdateIndex falls back to:
+// it submits an asynchronous `_reindex` task, registers a reindexJob, and
+// returns as soon as the task is accepted. watchReindexJob polls the task in
+// its own goroutine and atomically swaps the alias once Elasticsearch
+// reports it complete.
+//
+// Unlike UpdateIndex, which only reindexes when an in-place mapping update
+// fails, Rollover always builds a new index — useful for settings changes
+// (e.g. shard count) that an in-place mapping update can never satisfy.
+func Rollover(e *elasticsearch.Client) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		logs := logger.GetLogger(c)
+
+		alias := c.Param("alias")
+		if alias == "" {
+			logs.Error().Msg("alias name is required in URI")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusBadRequest,
+				Message: "alias name is required in URI",
+				Details: "alias name is required in URI",
+				Type:    cErr.BadRequestError.String(),
+			})
+			return
+		}
+
+		var req request.UpdateIndexRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			logs.Error().Err(err).Msg("invalid request payload")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusBadRequest,
+				Message: "invalid request payload",
+				Details: err.Error(),
+				Type:    cErr.BadRequestError.String(),
+			})
+			return
+		}
+
+		properties, analysis, autocompleteMeta, err := generateProperties(req.Fields)
+		if err != nil {
+			logs.Error().Err(err).Msg("failed to generate fields")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusBadRequest,
+				Message: "failed to generate fields",
+				Details: err.Error(),
+				Type:    cErr.BadRequestError.String(),
+			})
+			return
+		}
+
+		payload, err := json.Marshal(map[string]interface{}{
+			"settings": map[string]interface{}{"analysis": analysis},
+			"mappings": map[string]interface{}{
+				"properties": properties,
+				"_meta": map[string]interface{}{
+					"autocomplete_modes": autocompleteMeta,
+				},
+			},
+		})
+		if err != nil {
+			logs.Error().Err(err).Msg("failed to marshal index body")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusBadRequest,
+				Message: "failed to marshal index body",
+				Details: err.Error(),
+				Type:    cErr.BadRequestError.String(),
+			})
+			return
+		}
+
+		ec := elastic.New(e)
+
+		indexName, err := ec.ResolveAlias(c.Request.Context(), alias)
+		if err != nil {
+			var esErr cErr.ElasticsearchError
+			if errors.As(err, &esErr) {
+				logs.Error().Err(esErr.Details).Msg(esErr.Message)
+				response.SendErrorResponse(c, response.ErrResponse{
+					Code:    esErr.StatusCode,
+					Message: esErr.Message,
+					Details: esErr.Details.Error(),
+					Type:    esErr.Type.String(),
+				})
+				return
+			}
+			logs.Error().Err(err).Msg("failed to resolve alias")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusInternalServerError,
+				Message: "failed to resolve alias",
+				Details: err.Error(),
+				Type:    cErr.ServerError.String(),
+			})
+			return
+		}
+		if indexName == "" {
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusNotFound,
+				Message: "alias does not resolve to an existing index",
+				Details: "alias does not resolve to an existing index",
+				Type:    cErr.NotFoundError.String(),
+			})
+			return
+		}
+
+		newIndexName := fmt.Sprintf("%s-%s", alias, time.Now().Format("20060102150405"))
+		taskID, err := ec.StartReindex(c.Request.Context(), indexName, newIndexName, payload, elastic.ReindexOptions{
+			Conflicts:         req.Conflicts,
+			RequestsPerSecond: req.RequestsPerSecond,
+			Slices:            req.Slices,
+		})
+		if err != nil {
+			var esErr cErr.ElasticsearchError
+			if errors.As(err, &esErr) {
+				logs.Error().Err(esErr.Details).Msg(esErr.Message)
+				response.SendErrorResponse(c, response.ErrResponse{
+					Code:    esErr.StatusCode,
+					Message: esErr.Message,
+					Details: esErr.Details.Error(),
+					Type:    esErr.Type.String(),
+				})
+				return
+			}
+			logs.Error().Err(err).Msg("failed to start rollover")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusInternalServerError,
+				Message: "failed to start rollover",
+				Details: err.Error(),
+				Type:    cErr.ServerError.String(),
+			})
+			return
+		}
+
+		job := &reindexJob{
+			Alias:            alias,
+			OldIndex:         indexName,
+			NewIndex:         newIndexName,
+			TaskID:           taskID,
+			StartedAt:        time.Now(),
+			FailureThreshold: req.FailureThreshold,
+			GracePeriod:      time.Duration(req.GracePeriodSeconds) * time.Second,
+		}
+		defaultReindexRegistry.start(job)
+		go watchReindexJob(ec, job)
+
+		logs.Info().Str("task_id", taskID).Str("old_index", indexName).Str("new_index", newIndexName).Msg("rollover started")
+		c.JSON(http.StatusAccepted, response.SuccessResponse[reindexJob]{
+			Ts:      time.Now(),
+			Code:    http.StatusAccepted,
+			Message: "rollover started",
+			Data:    *job,
+		})
+	}
+}
+
+// AbortRollover cancels the in-flight rollover task tracked for alias (the
+// one started by Rollover or the UpdateIndex reindex fallback) and deletes
+// its half-built destination index, leaving the alias untouched on its
+// original index.
+func AbortRollover(e *elasticsearch.Client) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		logs := logger.GetLogger(c)
+
+		alias := c.Param("alias")
+		if alias == "" {
+			logs.Error().Msg("alias name is required in URI")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusBadRequest,
+				Message: "alias name is required in URI",
+				Details: "alias name is required in URI",
+				Type:    cErr.BadRequestError.String(),
+			})
+			return
+		}
+
+		job, ok := defaultReindexRegistry.get(alias)
+		if !ok {
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusNotFound,
+				Message: "no rollover in progress for alias",
+				Details: "no rollover in progress for alias",
+				Type:    cErr.NotFoundError.String(),
+			})
+			return
+		}
+
+		ec := elastic.New(e)
+		if err := ec.RollbackReindex(c.Request.Context(), job.TaskID, job.NewIndex); err != nil {
+			var esErr cErr.ElasticsearchError
+			if errors.As(err, &esErr) {
+				logs.Error().Err(esErr.Details).Msg(esErr.Message)
+				response.SendErrorResponse(c, response.ErrResponse{
+					Code:    esErr.StatusCode,
+					Message: esErr.Message,
+					Details: esErr.Details.Error(),
+					Type:    esErr.Type.String(),
+				})
+				return
+			}
+			logs.Error().Err(err).Msg("failed to abort rollover")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusInternalServerError,
+				Message: "failed to abort rollover",
+				Details: err.Error(),
+				Type:    cErr.ServerError.String(),
+			})
+			return
+		}
+		defaultReindexRegistry.finish(alias)
+
+		logs.Info().Str("alias", alias).Str("task_id", job.TaskID).Msg("rollover aborted")
+		c.JSON(http.StatusOK, response.SuccessResponse[any]{
+			Ts:      time.Now(),
+			Code:    http.StatusOK,
+			Message: "rollover aborted",
+		})
+	}
+}
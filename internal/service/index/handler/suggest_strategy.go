@@ -0,0 +1,102 @@
+package handler
+
+import "go-es/internal/service/index/model"
+
+// mappingSuggestStrategy builds the per-field mapping and any index-level
+// analysis settings a model.AutocompleteMode needs at index-creation time.
+// There's one implementation per model.AutocompleteMode, so adding a new
+// autocomplete strategy means adding a case to suggestStrategyFor rather
+// than threading another bool through generateProperties.
+type mappingSuggestStrategy interface {
+	// ApplyMapping mutates fieldMapping (already carrying "type" and any
+	// analyzer set by the caller) to add whatever sub-fields or
+	// type/analyzer overrides this strategy needs.
+	ApplyMapping(fieldMapping map[string]interface{})
+	// Settings returns the analysis settings (analyzers/filters) this
+	// strategy needs merged into the index's "settings.analysis", or nil.
+	Settings() map[string]interface{}
+}
+
+// suggestStrategyFor returns the mappingSuggestStrategy for mode, or nil if
+// mode is model.AutocompleteNone.
+func suggestStrategyFor(mode model.AutocompleteMode) mappingSuggestStrategy {
+	switch mode {
+	case model.AutocompleteCompletion:
+		return completionMappingStrategy{}
+	case model.AutocompleteEdgeNgram:
+		return edgeNgramMappingStrategy{}
+	case model.AutocompleteSearchAsYouType:
+		return searchAsYouTypeMappingStrategy{}
+	case model.AutocompletePhrase:
+		return phraseMappingStrategy{}
+	default:
+		return nil
+	}
+}
+
+// completionMappingStrategy adds a dedicated "suggest" completion sub-field,
+// queried via the completion suggester.
+type completionMappingStrategy struct{}
+
+func (completionMappingStrategy) ApplyMapping(fieldMapping map[string]interface{}) {
+	subFields, _ := fieldMapping["fields"].(map[string]interface{})
+	if subFields == nil {
+		subFields = map[string]interface{}{}
+		fieldMapping["fields"] = subFields
+	}
+	subFields["suggest"] = map[string]interface{}{"type": "completion"}
+}
+
+func (completionMappingStrategy) Settings() map[string]interface{} { return nil }
+
+// edgeNgramMappingStrategy analyzes the field itself with an edge_ngram
+// tokenizer at index time, so any prefix of an indexed token matches, and
+// re-analyzes the query with a plain standard analyzer.
+type edgeNgramMappingStrategy struct{}
+
+func (edgeNgramMappingStrategy) ApplyMapping(fieldMapping map[string]interface{}) {
+	fieldMapping["analyzer"] = "autocomplete_analyzer"
+	fieldMapping["search_analyzer"] = "standard_analyzer"
+}
+
+func (edgeNgramMappingStrategy) Settings() map[string]interface{} {
+	return map[string]interface{}{
+		"analyzer": map[string]interface{}{
+			"autocomplete_analyzer": map[string]interface{}{
+				"tokenizer": "edge_ngram",
+				"filter":    []string{"lowercase"},
+			},
+			"standard_analyzer": map[string]interface{}{
+				"tokenizer": "standard",
+				"filter":    []string{"lowercase"},
+			},
+		},
+		"filter": map[string]interface{}{
+			"autocomplete_filter": map[string]interface{}{
+				"type":     "edge_ngram",
+				"min_gram": 2,
+				"max_gram": 20,
+			},
+		},
+	}
+}
+
+// searchAsYouTypeMappingStrategy switches the field's type to
+// "search_as_you_type", which Elasticsearch itself indexes as shingled
+// sub-fields ("._2gram", "._3gram", "._index_prefix") for bool_prefix matching.
+type searchAsYouTypeMappingStrategy struct{}
+
+func (searchAsYouTypeMappingStrategy) ApplyMapping(fieldMapping map[string]interface{}) {
+	fieldMapping["type"] = "search_as_you_type"
+}
+
+func (searchAsYouTypeMappingStrategy) Settings() map[string]interface{} { return nil }
+
+// phraseMappingStrategy needs no special mapping - the phrase suggester runs
+// against the field's own analyzed text at query time - so it only exists to
+// keep AutocompleteMode's cases exhaustive.
+type phraseMappingStrategy struct{}
+
+func (phraseMappingStrategy) ApplyMapping(map[string]interface{}) {}
+
+func (phraseMappingStrategy) Settings() map[string]interface{} { return nil }
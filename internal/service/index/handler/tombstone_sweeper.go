@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"context"
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"go-es/internal/service/index/elastic"
+	"time"
+)
+
+// tombstoneSweepInterval is how often RunTombstoneSweeper scans for due
+// tombstones.
+const tombstoneSweepInterval = 5 * time.Minute
+
+// RunTombstoneSweeper polls ListDueTombstones every tombstoneSweepInterval
+// and physically deletes any index whose grace period has elapsed, clearing
+// its tombstone afterward. It blocks until ctx is cancelled, so callers
+// should invoke it with `go` — see server.NewServer.
+func RunTombstoneSweeper(ctx context.Context, e *elasticsearch.Client) {
+	logs := log.With().Str("component", "tombstone_sweeper").Logger()
+	ec := elastic.New(e)
+
+	ticker := time.NewTicker(tombstoneSweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			sweepTombstones(ctx, ec, logs)
+		}
+	}
+}
+
+// sweepTombstones deletes every tombstoned index whose grace period has
+// elapsed. It logs rather than returning an error since it runs detached
+// from any request.
+func sweepTombstones(ctx context.Context, ec elastic.Elastic, logs zerolog.Logger) {
+	due, err := ec.ListDueTombstones(ctx, time.Now())
+	if err != nil {
+		logs.Error().Err(err).Msg("failed to list due tombstones")
+		return
+	}
+
+	for _, t := range due {
+		if err := ec.DeleteIndex(ctx, t.Index); err != nil {
+			logs.Warn().Err(err).Str("alias", t.Alias).Str("index", t.Index).Msg("failed to delete tombstoned index")
+			continue
+		}
+		if err := ec.DeleteTombstone(ctx, t.Index); err != nil {
+			logs.Warn().Err(err).Str("index", t.Index).Msg("deleted tombstoned index but failed to clear tombstone record")
+			continue
+		}
+		logs.Info().Str("alias", t.Alias).Str("index", t.Index).Msg("tombstoned index deleted")
+	}
+}
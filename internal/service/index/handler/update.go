@@ -50,7 +50,7 @@ func UpdateIndex(e *elasticsearch.Client) func(c *gin.Context) {
 		}
 
 		// Generate properties for the updated alias
-		properties, err := generateProperties(req.Fields)
+		properties, analysis, autocompleteMeta, err := generateProperties(req.Fields)
 		if err != nil {
 			logs.Error().Err(err).Msg("failed to generate fields")
 			response.SendErrorResponse(c, response.ErrResponse{
@@ -65,32 +65,13 @@ func UpdateIndex(e *elasticsearch.Client) func(c *gin.Context) {
 		// Build Elasticsearch settings and mappings
 		indexBody := map[string]interface{}{
 			"settings": map[string]interface{}{
-				"analysis": map[string]interface{}{
-					"analyzer": map[string]interface{}{
-						"autocomplete_analyzer": map[string]interface{}{
-							"tokenizer": "edge_ngram",
-							"filter": []string{
-								"lowercase",
-							},
-						},
-						"standard_analyzer": map[string]interface{}{
-							"tokenizer": "standard",
-							"filter": []string{
-								"lowercase",
-							},
-						},
-					},
-					"filter": map[string]interface{}{
-						"autocomplete_filter": map[string]interface{}{
-							"type":     "edge_ngram",
-							"min_gram": 2,
-							"max_gram": 20,
-						},
-					},
-				},
+				"analysis": analysis,
 			},
 			"mappings": map[string]interface{}{
 				"properties": properties,
+				"_meta": map[string]interface{}{
+					"autocomplete_modes": autocompleteMeta,
+				},
 			},
 		}
 
@@ -195,32 +176,19 @@ func UpdateIndex(e *elasticsearch.Client) func(c *gin.Context) {
 		if err := ec.UpdateIndexMappings(c.Request.Context(), indexName, payload); err != nil {
 			logs.Warn().Err(err).Msg("failed to update index mappings")
 
-			// If updating mappings fails, reindex the data
+			// If updating mappings fails in place, the data has to be
+			// reindexed into a new physical index. This can take a long
+			// time on a nontrivial dataset, so it runs as an asynchronous
+			// `_reindex` task: the handler returns as soon as the task is
+			// submitted, and a background worker swaps the alias onto the
+			// new index once Elasticsearch reports the task complete.
 			newIndexName := fmt.Sprintf("%s_%s", alias, time.Now().Format("20060102150405")) // Use timestamp as suffix
-			if err := ec.Reindex(c.Request.Context(), indexName, newIndexName, payload); err != nil {
-				var esErr cErr.ElasticsearchError
-				if errors.As(err, &esErr) {
-					logs.Error().Err(esErr.Details).Msg(esErr.Message)
-					response.SendErrorResponse(c, response.ErrResponse{
-						Code:    esErr.StatusCode,
-						Message: esErr.Message,
-						Details: esErr.Details.Error(),
-						Type:    esErr.Type.String(),
-					})
-					return
-				}
-				logs.Error().Err(err).Msg("failed to reindex data")
-				response.SendErrorResponse(c, response.ErrResponse{
-					Code:    http.StatusInternalServerError,
-					Message: "failed to reindex data",
-					Details: err.Error(),
-					Type:    cErr.ServerError.String(),
-				})
-				return
-			}
-
-			// Update the alias to point to the new alias
-			if err := ec.UpdateAlias(c.Request.Context(), alias, newIndexName); err != nil {
+			taskID, err := ec.StartReindex(c.Request.Context(), indexName, newIndexName, payload, elastic.ReindexOptions{
+				Conflicts:         req.Conflicts,
+				RequestsPerSecond: req.RequestsPerSecond,
+				Slices:            req.Slices,
+			})
+			if err != nil {
 				var esErr cErr.ElasticsearchError
 				if errors.As(err, &esErr) {
 					logs.Error().Err(esErr.Details).Msg(esErr.Message)
@@ -232,43 +200,34 @@ func UpdateIndex(e *elasticsearch.Client) func(c *gin.Context) {
 					})
 					return
 				}
-				logs.Error().Err(err).Msg("failed to update alias")
+				logs.Error().Err(err).Msg("failed to start reindex")
 				response.SendErrorResponse(c, response.ErrResponse{
 					Code:    http.StatusInternalServerError,
-					Message: "failed to update alias",
+					Message: "failed to start reindex",
 					Details: err.Error(),
 					Type:    cErr.ServerError.String(),
 				})
 				return
 			}
 
-			// Delete the old alias
-			if err := ec.DeleteIndex(c.Request.Context(), indexName); err != nil {
-				var esErr cErr.ElasticsearchError
-				if errors.As(err, &esErr) {
-					logs.Error().Err(esErr.Details).Msg(esErr.Message)
-					response.SendErrorResponse(c, response.ErrResponse{
-						Code:    esErr.StatusCode,
-						Message: esErr.Message,
-						Details: esErr.Details.Error(),
-						Type:    esErr.Type.String(),
-					})
-					return
-				}
-				logs.Error().Err(err).Msg("failed to delete alias")
-				response.SendErrorResponse(c, response.ErrResponse{
-					Code:    http.StatusInternalServerError,
-					Message: "failed to delete alias",
-					Details: err.Error(),
-					Type:    cErr.ServerError.String(),
-				})
-				return
+			job := &reindexJob{
+				Alias:            alias,
+				OldIndex:         indexName,
+				NewIndex:         newIndexName,
+				TaskID:           taskID,
+				StartedAt:        time.Now(),
+				FailureThreshold: req.FailureThreshold,
+				GracePeriod:      time.Duration(req.GracePeriodSeconds) * time.Second,
 			}
+			defaultReindexRegistry.start(job)
+			go watchReindexJob(ec, job)
 
-			c.JSON(http.StatusOK, response.SuccessResponse[any]{
+			logs.Info().Str("task_id", taskID).Str("old_index", indexName).Str("new_index", newIndexName).Msg("reindex started")
+			c.JSON(http.StatusAccepted, response.SuccessResponse[reindexJob]{
 				Ts:      time.Now(),
-				Code:    http.StatusOK,
-				Message: "Index updated successfully (re-indexed)",
+				Code:    http.StatusAccepted,
+				Message: "index update requires reindexing, reindex started",
+				Data:    *job,
 			})
 			return
 		}
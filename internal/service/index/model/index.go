@@ -1,13 +1,68 @@
 package model
 
+// AutocompleteMode selects which suggester/mapping strategy a text field
+// uses for autocomplete: the completion suggester, an edge_ngram-analyzed
+// field, a search_as_you_type field, or a phrase/term suggester. The zero
+// value disables autocomplete for the field.
+type AutocompleteMode string
+
+const (
+	// AutocompleteNone disables autocomplete for the field.
+	AutocompleteNone AutocompleteMode = ""
+	// AutocompleteCompletion uses Elasticsearch's dedicated completion
+	// suggester on a "suggest" sub-field.
+	AutocompleteCompletion AutocompleteMode = "completion"
+	// AutocompleteEdgeNgram analyzes the field itself with an edge_ngram
+	// tokenizer at index time, matched with a standard analyzer at query time.
+	AutocompleteEdgeNgram AutocompleteMode = "edge_ngram"
+	// AutocompleteSearchAsYouType maps the field as a "search_as_you_type"
+	// type, matched with a bool_prefix multi_match query.
+	AutocompleteSearchAsYouType AutocompleteMode = "search_as_you_type"
+	// AutocompletePhrase uses the phrase suggester for did-you-mean style
+	// corrections instead of prefix completion.
+	AutocompletePhrase AutocompleteMode = "phrase"
+)
+
 // FieldConfig defines the configuration for a field in the index
 type FieldConfig struct {
-	Type           string                 `json:"type"`
-	Analyzer       string                 `json:"analyzer,omitempty"`
-	SearchAnalyzer string                 `json:"search_analyzer,omitempty"`
-	Autocomplete   bool                   `json:"autocomplete,omitempty"`
-	Search         bool                   `json:"search,omitempty"`
-	Properties     map[string]FieldConfig `json:"properties,omitempty"` // For nested fields
+	Type             string                 `json:"type"`
+	Analyzer         string                 `json:"analyzer,omitempty"`
+	SearchAnalyzer   string                 `json:"search_analyzer,omitempty"`
+	AutocompleteMode AutocompleteMode       `json:"autocomplete_mode,omitempty"` // selects the autocomplete strategy; "" disables it
+	Search           bool                   `json:"search,omitempty"`
+	Properties       map[string]FieldConfig `json:"properties,omitempty"` // For nested/object fields
+
+	// Nested maps the field as Elasticsearch's "nested" type instead of the
+	// default "object", so each entry in Properties is indexed and queried
+	// independently (required for array-of-objects fields queried with
+	// nested/inner_hits).
+	Nested bool `json:"nested,omitempty"`
+	// Fields declares multi-fields: alternate ways to index the same value
+	// under "fields", e.g. a "keyword" sub-field on a "text" field so both
+	// "title" (full-text) and "title.keyword" (exact/sort) are queryable.
+	Fields map[string]FieldConfig `json:"fields,omitempty"`
+	// CopyTo names other fields this field's value is additionally copied
+	// into at index time (Elasticsearch's "copy_to").
+	CopyTo []string `json:"copy_to,omitempty"`
+	// Normalizer names a custom normalizer applied to a keyword field before
+	// indexing and at query time.
+	Normalizer string `json:"normalizer,omitempty"`
+	// NullValue substitutes for a literal JSON null so the field stays
+	// searchable.
+	NullValue any `json:"null_value,omitempty"`
+	// IgnoreAbove skips indexing keyword values longer than this many
+	// characters, leaving them stored but not searchable.
+	IgnoreAbove int `json:"ignore_above,omitempty"`
+	// Join configures this field as a parent/child join field; when set it
+	// overrides Type with Elasticsearch's "join" type.
+	Join *JoinConfig `json:"join,omitempty"`
+}
+
+// JoinConfig declares the parent/child relations of a "join" field, mapping
+// each parent relation name to the child relation names that may reference
+// it, e.g. {"question": ["answer"]}.
+type JoinConfig struct {
+	Relations map[string][]string `json:"relations"`
 }
 
 // Index defines the request structure for creating an index
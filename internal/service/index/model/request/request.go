@@ -4,5 +4,24 @@ import "go-es/internal/service/index/model"
 
 // UpdateIndexRequest defines the request structure for updating an index.
 type UpdateIndexRequest struct {
-	Fields map[string]model.FieldConfig `json:"fields" binding:"required"` // Updated fields configuration
+	Fields             map[string]model.FieldConfig `json:"fields" binding:"required"` // Updated fields configuration
+	Conflicts          string                       `json:"conflicts,omitempty"`       // "proceed" or "abort" (default), forwarded to _reindex if a reindex is required
+	RequestsPerSecond  float64                      `json:"requests_per_second,omitempty"`
+	Slices             interface{}                  `json:"slices,omitempty"`               // "auto" or an integer, forwarded to _reindex if a reindex is required
+	FailureThreshold   int64                        `json:"failure_threshold,omitempty"`    // roll back instead of swapping if the task reports more than this many failures; 0 disables the check
+	GracePeriodSeconds int                          `json:"grace_period_seconds,omitempty"` // delay before deleting the old physical index after a successful swap; 0 deletes immediately
+}
+
+// ReindexRequest defines the request structure for starting a zero-downtime
+// reindex of an alias into a new set of mappings.
+type ReindexRequest struct {
+	Fields             map[string]model.FieldConfig `json:"fields" binding:"required"` // New mappings for the destination index
+	Conflicts          string                       `json:"conflicts,omitempty"`       // "proceed" or "abort" (default)
+	Refresh            bool                         `json:"refresh,omitempty"`
+	RequestsPerSecond  float64                      `json:"requests_per_second,omitempty"`
+	Slices             interface{}                  `json:"slices,omitempty"`               // "auto" or an integer
+	Script             string                       `json:"script,omitempty"`               // optional inline painless script
+	DeleteOld          bool                         `json:"delete_old,omitempty"`           // not read by StartReindex; SwapReindexAlias takes ?delete_old= instead
+	FailureThreshold   int64                        `json:"failure_threshold,omitempty"`    // not read by StartReindex; SwapReindexAlias takes ?failure_threshold= instead
+	GracePeriodSeconds int                          `json:"grace_period_seconds,omitempty"` // not read by StartReindex; SwapReindexAlias takes ?grace_period_seconds= instead
 }
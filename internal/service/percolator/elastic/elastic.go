@@ -0,0 +1,36 @@
+package elastic
+
+import (
+	"context"
+	"github.com/elastic/go-elasticsearch/v8"
+)
+
+// MatchedQuery is a single saved query that matched a percolated document.
+type MatchedQuery struct {
+	Name  string                 `json:"name"`  // The registered query's name (its document ID)
+	Query map[string]interface{} `json:"query"` // The query body as originally registered
+}
+
+// Elastic is an interface to interact with Elasticsearch's percolator
+// feature: registering saved queries against an alias and checking which of
+// them match a newly ingested document.
+type Elastic interface {
+	// RegisterQuery saves query under name in alias's percolator index, so
+	// future Percolate calls can match against it. Calling it again with an
+	// existing name replaces the saved query.
+	RegisterQuery(ctx context.Context, alias, name string, query map[string]interface{}) error
+	// DeleteQuery removes the saved query named name from alias.
+	DeleteQuery(ctx context.Context, alias, name string) error
+	// Percolate runs doc through every query saved in alias and returns the
+	// ones that match.
+	Percolate(ctx context.Context, alias string, doc map[string]interface{}) ([]MatchedQuery, error)
+}
+
+type elastic struct {
+	client *elasticsearch.Client
+}
+
+// New creates a new instance of Elastic.
+func New(client *elasticsearch.Client) Elastic {
+	return &elastic{client: client}
+}
@@ -0,0 +1,134 @@
+package elastic
+
+import (
+	"bytes"
+	"context"
+	"github.com/goccy/go-json"
+	"go-es/internal/errors"
+	"net/http"
+)
+
+// RegisterQuery saves query under name in alias, so a later Percolate call
+// against alias can match it. The query is stored as the "query" field of a
+// document whose ID is name, which must be mapped as Elasticsearch's
+// "percolator" type (see model.FieldConfig's "percolator" field type).
+// Calling it again with an existing name replaces the saved query.
+func (e *elastic) RegisterQuery(ctx context.Context, alias, name string, query map[string]interface{}) error {
+	body, err := json.Marshal(map[string]interface{}{"query": query})
+	if err != nil {
+		return errors.ElasticsearchError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "failed to marshal percolator query",
+			Details:    err,
+			Type:       errors.MarhshalingError,
+		}
+	}
+
+	res, err := e.client.Index(
+		alias,
+		bytes.NewReader(body),
+		e.client.Index.WithContext(ctx),
+		e.client.Index.WithDocumentID(name),
+		e.client.Index.WithRefresh("true"),
+	)
+	if err != nil {
+		return errors.NewConnectionError(err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.ParseElasticsearchError(res, "failed to register percolator query")
+	}
+
+	return nil
+}
+
+// DeleteQuery removes the saved query named name from alias.
+func (e *elastic) DeleteQuery(ctx context.Context, alias, name string) error {
+	res, err := e.client.Delete(
+		alias,
+		name,
+		e.client.Delete.WithContext(ctx),
+		e.client.Delete.WithRefresh("true"),
+	)
+	if err != nil {
+		return errors.NewConnectionError(err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return errors.ElasticsearchError{
+			StatusCode: http.StatusNotFound,
+			Message:    "percolator query not found",
+			Details:    err,
+			Type:       errors.NotFoundError,
+		}
+	}
+	if res.IsError() {
+		return errors.ParseElasticsearchError(res, "failed to delete percolator query")
+	}
+
+	return nil
+}
+
+// Percolate runs doc through every query saved in alias via Elasticsearch's
+// "percolate" query and returns the ones that match.
+func (e *elastic) Percolate(ctx context.Context, alias string, doc map[string]interface{}) ([]MatchedQuery, error) {
+	searchBody := map[string]interface{}{
+		"query": map[string]interface{}{
+			"percolate": map[string]interface{}{
+				"field":    "query",
+				"document": doc,
+			},
+		},
+	}
+	payload, err := json.Marshal(searchBody)
+	if err != nil {
+		return nil, errors.ElasticsearchError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "failed to marshal percolate query",
+			Details:    err,
+			Type:       errors.MarhshalingError,
+		}
+	}
+
+	res, err := e.client.Search(
+		e.client.Search.WithContext(ctx),
+		e.client.Search.WithIndex(alias),
+		e.client.Search.WithBody(bytes.NewReader(payload)),
+	)
+	if err != nil {
+		return nil, errors.NewConnectionError(err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, errors.ParseElasticsearchError(res, "failed to percolate document")
+	}
+
+	var esResponse struct {
+		Hits struct {
+			Hits []struct {
+				ID     string `json:"_id"`
+				Source struct {
+					Query map[string]interface{} `json:"query"`
+				} `json:"_source"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&esResponse); err != nil {
+		return nil, errors.ElasticsearchError{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "failed to decode percolate response",
+			Details:    err,
+			Type:       errors.DecodeError,
+		}
+	}
+
+	matches := make([]MatchedQuery, 0, len(esResponse.Hits.Hits))
+	for _, hit := range esResponse.Hits.Hits {
+		matches = append(matches, MatchedQuery{Name: hit.ID, Query: hit.Source.Query})
+	}
+
+	return matches, nil
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"errors"
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/gin-gonic/gin"
+	cErr "go-es/internal/errors"
+	"go-es/internal/response"
+	"go-es/internal/service/percolator/elastic"
+	"go-es/internal/service/percolator/model/request"
+	"go-es/logger"
+	"net/http"
+	"time"
+)
+
+// percolateResponse reports which saved queries, if any, matched the
+// percolated document.
+type percolateResponse struct {
+	Matches []elastic.MatchedQuery `json:"matches"`
+}
+
+// Percolate matches the document in the request body against every query
+// previously saved in alias via RegisterQuery, returning the ones that match.
+func Percolate(e *elasticsearch.Client) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		logs := logger.GetLogger(c)
+
+		alias := c.Param("alias")
+		if alias == "" {
+			logs.Error().Msg("alias name is required in URI")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusBadRequest,
+				Message: "alias name is required in URI",
+				Details: "alias name is required in URI",
+				Type:    cErr.BadRequestError.String(),
+			})
+			return
+		}
+
+		var req request.PercolateRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			logs.Error().Err(err).Msg("invalid request payload")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusBadRequest,
+				Message: "invalid JSON payload",
+				Details: err.Error(),
+				Type:    cErr.BadRequestError.String(),
+			})
+			return
+		}
+
+		ec := elastic.New(e)
+		matches, err := ec.Percolate(c.Request.Context(), alias, req.Document)
+		if err != nil {
+			var esErr cErr.ElasticsearchError
+			if errors.As(err, &esErr) {
+				logs.Error().Err(esErr.Details).Msg(esErr.Message)
+				response.SendErrorResponse(c, response.ErrResponse{
+					Code:    esErr.StatusCode,
+					Message: esErr.Message,
+					Details: esErr.Details.Error(),
+					Type:    esErr.Type.String(),
+				})
+				return
+			}
+			logs.Error().Err(err).Msg("failed to percolate document")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusInternalServerError,
+				Message: "failed to percolate document",
+				Details: err.Error(),
+				Type:    cErr.ServerError.String(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, response.SuccessResponse[percolateResponse]{
+			Ts:      time.Now(),
+			Code:    http.StatusOK,
+			Message: "document percolated",
+			Data:    percolateResponse{Matches: matches},
+		})
+	}
+}
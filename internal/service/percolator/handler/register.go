@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"errors"
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/gin-gonic/gin"
+	cErr "go-es/internal/errors"
+	"go-es/internal/response"
+	"go-es/internal/service/percolator/elastic"
+	"go-es/internal/service/percolator/model/request"
+	"go-es/logger"
+	"net/http"
+	"time"
+)
+
+// RegisterQuery saves a query under alias so a later Percolate call can
+// match it against newly ingested documents.
+func RegisterQuery(e *elasticsearch.Client) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		logs := logger.GetLogger(c)
+
+		alias := c.Param("alias")
+		if alias == "" {
+			logs.Error().Msg("alias name is required in URI")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusBadRequest,
+				Message: "alias name is required in URI",
+				Details: "alias name is required in URI",
+				Type:    cErr.BadRequestError.String(),
+			})
+			return
+		}
+
+		var req request.RegisterQueryRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			logs.Error().Err(err).Msg("invalid request payload")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusBadRequest,
+				Message: "invalid JSON payload",
+				Details: err.Error(),
+				Type:    cErr.BadRequestError.String(),
+			})
+			return
+		}
+
+		ec := elastic.New(e)
+		if err := ec.RegisterQuery(c.Request.Context(), alias, req.Name, req.Query); err != nil {
+			var esErr cErr.ElasticsearchError
+			if errors.As(err, &esErr) {
+				logs.Error().Err(esErr.Details).Msg(esErr.Message)
+				response.SendErrorResponse(c, response.ErrResponse{
+					Code:    esErr.StatusCode,
+					Message: esErr.Message,
+					Details: esErr.Details.Error(),
+					Type:    esErr.Type.String(),
+				})
+				return
+			}
+			logs.Error().Err(err).Msg("failed to register percolator query")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusInternalServerError,
+				Message: "failed to register percolator query",
+				Details: err.Error(),
+				Type:    cErr.ServerError.String(),
+			})
+			return
+		}
+
+		logs.Info().Str("name", req.Name).Msg("percolator query registered")
+		c.JSON(http.StatusOK, response.SuccessResponse[any]{
+			Ts:      time.Now(),
+			Code:    http.StatusOK,
+			Message: "percolator query registered",
+		})
+	}
+}
+
+// DeleteQuery removes a query previously saved via RegisterQuery.
+func DeleteQuery(e *elasticsearch.Client) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		logs := logger.GetLogger(c)
+
+		alias := c.Param("alias")
+		name := c.Param("name")
+		if alias == "" || name == "" {
+			logs.Error().Msg("alias and query name are required in URI")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusBadRequest,
+				Message: "alias and query name are required in URI",
+				Details: "alias and query name are required in URI",
+				Type:    cErr.BadRequestError.String(),
+			})
+			return
+		}
+
+		ec := elastic.New(e)
+		if err := ec.DeleteQuery(c.Request.Context(), alias, name); err != nil {
+			var esErr cErr.ElasticsearchError
+			if errors.As(err, &esErr) {
+				logs.Error().Err(esErr.Details).Msg(esErr.Message)
+				response.SendErrorResponse(c, response.ErrResponse{
+					Code:    esErr.StatusCode,
+					Message: esErr.Message,
+					Details: esErr.Details.Error(),
+					Type:    esErr.Type.String(),
+				})
+				return
+			}
+			logs.Error().Err(err).Msg("failed to delete percolator query")
+			response.SendErrorResponse(c, response.ErrResponse{
+				Code:    http.StatusInternalServerError,
+				Message: "failed to delete percolator query",
+				Details: err.Error(),
+				Type:    cErr.ServerError.String(),
+			})
+			return
+		}
+
+		logs.Info().Str("name", name).Msg("percolator query deleted")
+		c.JSON(http.StatusOK, response.SuccessResponse[any]{
+			Ts:      time.Now(),
+			Code:    http.StatusOK,
+			Message: "percolator query deleted",
+		})
+	}
+}
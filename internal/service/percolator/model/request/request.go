@@ -0,0 +1,14 @@
+package request
+
+// RegisterQueryRequest defines the request structure for registering a
+// saved percolator query.
+type RegisterQueryRequest struct {
+	Name  string                 `json:"name" binding:"required"`  // Name the query is saved and addressed by
+	Query map[string]interface{} `json:"query" binding:"required"` // Elasticsearch query to save
+}
+
+// PercolateRequest defines the request structure for matching a document
+// against every query saved in an alias.
+type PercolateRequest struct {
+	Document map[string]interface{} `json:"document" binding:"required"` // Document to percolate
+}
@@ -8,7 +8,9 @@ import (
 	"github.com/spf13/viper"
 	"go-es/config"
 	"go-es/internal/esc"
+	"go-es/internal/notify"
 	"go-es/internal/response"
+	"go-es/internal/service/health"
 	"go-es/logger"
 	"go-es/server"
 	"os"
@@ -42,13 +44,20 @@ func main() {
 	response.Init(a.cfg.DetailError)
 
 	logger.InitializeLogger(a.cfg.LogLevel)
+	notify.Initialize(a.cfg.Notifications)
 
-	if a.ec, err = esc.NewClient(a.cfg.ElasticSearch); err != nil {
+	var version *esc.ClusterVersion
+	if a.ec, version, err = esc.NewClientWithVersionCheck(a.cfg.ElasticSearch); err != nil {
 		log.Fatal().Err(err).Msg("failed to create an elastic client")
 	}
+	log.Info().Str("cluster_version", version.Number).Msg("connected to elasticsearch")
 
 	a.srv = server.NewServer(a.cfg.Server, a.ec)
 
+	healthCtx, stopHealthCheck := context.WithCancel(context.Background())
+	defer stopHealthCheck()
+	health.Initialize(healthCtx, a.ec, a.cfg.HealthCheck)
+
 	// Set up signal handling
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	sig := make(chan os.Signal, 1)
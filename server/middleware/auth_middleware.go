@@ -1,6 +1,7 @@
 package middlewares
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v4"
@@ -8,10 +9,48 @@ import (
 	cErr "go-es/internal/errors"
 	"go-es/internal/response"
 	"net/http"
+	"strings"
+	"time"
 )
 
-// Auth is a JWT authentication middleware
-func Auth(secret string) gin.HandlerFunc {
+// ClaimsKey is the gin context key under which the JWT claims parsed by Auth
+// are stored, for RequireScopes/RequireRoles and downstream handlers.
+const ClaimsKey = "auth_claims"
+
+// AuthConfig configures the Auth middleware's token validation.
+type AuthConfig struct {
+	SigningMethod  string        `mapstructure:"signing_method"`   // "HS256" (default), "RS256", or "ES256"
+	Secret         string        `mapstructure:"secret"`           // HMAC secret, used when SigningMethod is HS256
+	JWKSURL        string        `mapstructure:"jwks_url"`         // JWKS endpoint, used when SigningMethod is RS256/ES256
+	JWKSCacheTTL   time.Duration `mapstructure:"jwks_cache_ttl"`   // how long cached JWKS keys are trusted before a refresh
+	Issuer         string        `mapstructure:"issuer"`           // expected `iss` claim; empty skips the check
+	Audience       string        `mapstructure:"audience"`         // expected `aud` claim; empty skips the check
+	Leeway         time.Duration `mapstructure:"leeway"`           // clock skew allowed when validating exp/nbf
+	RolesClaimPath string        `mapstructure:"roles_claim_path"` // dot path to the roles claim, e.g. "realm_access.roles"
+}
+
+// Enabled reports whether cfg carries enough configuration to validate
+// tokens.
+func (cfg AuthConfig) Enabled() bool {
+	return cfg.Secret != "" || cfg.JWKSURL != ""
+}
+
+// Auth is a JWT authentication middleware. It supports HS256 via a static
+// shared secret (the default) and RS256/ES256 via keys fetched from a JWKS
+// endpoint and cached by the token's `kid` header. On success it validates
+// iss/aud/exp/nbf (with configurable leeway) and stores the parsed claims on
+// the gin context under ClaimsKey.
+func Auth(cfg AuthConfig) gin.HandlerFunc {
+	method := cfg.SigningMethod
+	if method == "" {
+		method = "HS256"
+	}
+
+	var jwks *jwksCache
+	if cfg.JWKSURL != "" {
+		jwks = newJWKSCache(cfg.JWKSURL, cfg.JWKSCacheTTL)
+	}
+
 	return func(c *gin.Context) {
 		// Check if the Authorization header exists
 		authHeader := c.GetHeader("Authorization")
@@ -40,20 +79,35 @@ func Auth(secret string) gin.HandlerFunc {
 			return
 		}
 
-		// Extract the token part
 		tokenStr := authHeader[7:]
 
-		// Parse the token
-		token, err := jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
-			// Ensure the token's signing method is HMAC (HS256)
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		claims := jwt.MapClaims{}
+		_, err := jwt.ParseWithClaims(tokenStr, claims, func(token *jwt.Token) (interface{}, error) {
+			switch method {
+			case "HS256":
+				if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+					return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+				}
+				return []byte(cfg.Secret), nil
+			case "RS256", "ES256":
+				kid, _ := token.Header["kid"].(string)
+				if kid == "" {
+					return nil, fmt.Errorf("token is missing a kid header")
+				}
+				if jwks == nil {
+					return nil, fmt.Errorf("jwks_url is not configured")
+				}
+				return jwks.Get(kid)
+			default:
+				return nil, fmt.Errorf("unsupported signing method: %s", method)
 			}
-			// Return the secret key to validate the JWT
-			return []byte(secret), nil
-		})
+		}, jwt.WithoutClaimsValidation())
 
-		if err != nil || !token.Valid {
+		if err == nil {
+			err = validateClaims(claims, cfg)
+		}
+
+		if err != nil {
 			logs.Debug().Err(err).Msg("Invalid token")
 			response.SendErrorResponse(c, response.ErrResponse{
 				Code:    http.StatusUnauthorized,
@@ -65,7 +119,197 @@ func Auth(secret string) gin.HandlerFunc {
 			return
 		}
 
-		// Token is valid, continue processing the request
+		c.Set(ClaimsKey, claims)
+		c.Next()
+	}
+}
+
+// validateClaims checks exp/nbf (honoring cfg.Leeway) and, when configured,
+// iss/aud. jwt.WithoutClaimsValidation skips the library's own (non
+// leeway-aware) checks so this is the only place those claims are enforced.
+func validateClaims(claims jwt.MapClaims, cfg AuthConfig) error {
+	now := time.Now()
+
+	if exp, ok := numericClaim(claims, "exp"); ok && now.After(time.Unix(exp, 0).Add(cfg.Leeway)) {
+		return fmt.Errorf("token is expired")
+	}
+	if nbf, ok := numericClaim(claims, "nbf"); ok && now.Before(time.Unix(nbf, 0).Add(-cfg.Leeway)) {
+		return fmt.Errorf("token is not valid yet")
+	}
+	if cfg.Issuer != "" {
+		if iss, _ := claims["iss"].(string); iss != cfg.Issuer {
+			return fmt.Errorf("unexpected issuer")
+		}
+	}
+	if cfg.Audience != "" && !audienceMatches(claims["aud"], cfg.Audience) {
+		return fmt.Errorf("unexpected audience")
+	}
+	return nil
+}
+
+// numericClaim reads a numeric claim, which jwt.MapClaims may decode as
+// either float64 or json.Number depending on the parser configuration.
+func numericClaim(claims jwt.MapClaims, key string) (int64, bool) {
+	switch v := claims[key].(type) {
+	case float64:
+		return int64(v), true
+	case json.Number:
+		i, err := v.Int64()
+		return i, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// audienceMatches reports whether expected appears in aud, which per the JWT
+// spec may be either a single string or an array of strings.
+func audienceMatches(aud interface{}, expected string) bool {
+	switch v := aud.(type) {
+	case string:
+		return v == expected
+	case []interface{}:
+		for _, a := range v {
+			if s, ok := a.(string); ok && s == expected {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Claims returns the JWT claims parsed by Auth for the current request, or
+// nil if Auth did not run for this route.
+func Claims(c *gin.Context) jwt.MapClaims {
+	v, ok := c.Get(ClaimsKey)
+	if !ok {
+		return nil
+	}
+	claims, _ := v.(jwt.MapClaims)
+	return claims
+}
+
+// RequireRoles returns a chainable middleware that aborts with 403 unless
+// the caller's token carries at least one of roles, read from claimPath (a
+// dot-separated path such as "realm_access.roles" for Keycloak).
+func RequireRoles(claimPath string, roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !claimPathIntersects(c, claimPath, roles) {
+			forbidden(c, "insufficient role")
+			return
+		}
 		c.Next()
 	}
 }
+
+// RequireScopes returns a chainable middleware that aborts with 403 unless
+// the caller's token carries at least one of scopes, read from the standard
+// OAuth2 `scope` claim (a space-separated string).
+func RequireScopes(scopes ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims := Claims(c)
+		scopeStr, _ := claims["scope"].(string)
+		granted := strings.Fields(scopeStr)
+
+		if !intersects(granted, scopes) {
+			forbidden(c, "insufficient scope")
+			return
+		}
+		c.Next()
+	}
+}
+
+// claimPathIntersects reads the claim at dotted path claimPath (e.g.
+// "realm_access.roles") from the current request's claims and reports
+// whether it shares at least one value with want.
+func claimPathIntersects(c *gin.Context, claimPath string, want []string) bool {
+	claims := Claims(c)
+	if claims == nil {
+		return false
+	}
+
+	var cur interface{} = map[string]interface{}(claims)
+	for _, segment := range strings.Split(claimPath, ".") {
+		m, ok := cur.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		cur, ok = m[segment]
+		if !ok {
+			return false
+		}
+	}
+
+	values, ok := cur.([]interface{})
+	if !ok {
+		return false
+	}
+	have := make([]string, 0, len(values))
+	for _, v := range values {
+		if s, ok := v.(string); ok {
+			have = append(have, s)
+		}
+	}
+	return intersects(have, want)
+}
+
+// intersects reports whether have and want share at least one element.
+func intersects(have, want []string) bool {
+	for _, w := range want {
+		for _, h := range have {
+			if h == w {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IndicesClaim returns the string values of the token's "indices" claim,
+// used to scope index/alias access by tenant. It returns nil when Auth did
+// not run or the claim is absent, which callers should treat as
+// "unrestricted" rather than "deny all".
+func IndicesClaim(c *gin.Context) []string {
+	claims := Claims(c)
+	if claims == nil {
+		return nil
+	}
+	raw, ok := claims["indices"].([]interface{})
+	if !ok {
+		return nil
+	}
+	out := make([]string, 0, len(raw))
+	for _, v := range raw {
+		if s, ok := v.(string); ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// AuthorizeIndex checks alias against the caller's "indices" claim. If the
+// claim is present and does not list alias (or "*"), it writes a 403
+// response and returns false; callers should return immediately without
+// writing their own response in that case. A missing claim means no
+// restriction is enforced.
+func AuthorizeIndex(c *gin.Context, alias string) bool {
+	allowed := IndicesClaim(c)
+	if allowed == nil {
+		return true
+	}
+	if intersects(allowed, []string{alias, "*"}) {
+		return true
+	}
+	forbidden(c, fmt.Sprintf("not authorized for index %q", alias))
+	return false
+}
+
+// forbidden writes a 403 response and aborts the chain.
+func forbidden(c *gin.Context, msg string) {
+	response.SendErrorResponse(c, response.ErrResponse{
+		Code:    http.StatusForbidden,
+		Message: msg,
+		Details: msg,
+		Type:    cErr.ForbiddenError.String(),
+	})
+	c.Abort()
+}
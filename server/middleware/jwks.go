@@ -0,0 +1,166 @@
+package middlewares
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// jwk is a single JSON Web Key as served by a JWKS endpoint.
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// jwksDocument is the top-level JWKS response body.
+type jwksDocument struct {
+	Keys []jwk `json:"keys"`
+}
+
+// jwksCache fetches public keys from a JWKS endpoint and caches them by
+// `kid`. Keys are refreshed in the background every ttl, with a lazy
+// fallback refresh if a lookup misses or the cache has gone stale.
+type jwksCache struct {
+	url string
+	ttl time.Duration
+
+	mu        sync.RWMutex
+	keys      map[string]interface{}
+	fetchedAt time.Time
+}
+
+// newJWKSCache starts a background refresh loop for url and returns the
+// cache immediately; the first Get call will block on the initial fetch if
+// the background refresh hasn't completed yet.
+func newJWKSCache(url string, ttl time.Duration) *jwksCache {
+	if ttl <= 0 {
+		ttl = 10 * time.Minute
+	}
+	c := &jwksCache{url: url, ttl: ttl, keys: map[string]interface{}{}}
+
+	go func() {
+		for {
+			_ = c.refresh()
+			time.Sleep(c.ttl)
+		}
+	}()
+
+	return c
+}
+
+// Get returns the public key for kid, refreshing the cache first if it has
+// gone stale or does not yet contain kid.
+func (c *jwksCache) Get(kid string) (interface{}, error) {
+	c.mu.RLock()
+	key, ok := c.keys[kid]
+	stale := time.Since(c.fetchedAt) > c.ttl
+	c.mu.RUnlock()
+
+	if ok && !stale {
+		return key, nil
+	}
+
+	if err := c.refresh(); err != nil && !ok {
+		return nil, err
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if key, ok = c.keys[kid]; !ok {
+		return nil, fmt.Errorf("jwks: no key found for kid %q", kid)
+	}
+	return key, nil
+}
+
+// refresh fetches and parses the JWKS document, replacing the cached key
+// set on success.
+func (c *jwksCache) refresh() error {
+	res, err := http.Get(c.url)
+	if err != nil {
+		return fmt.Errorf("jwks: failed to fetch %s: %w", c.url, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 300 {
+		return fmt.Errorf("jwks: unexpected status %d fetching %s", res.StatusCode, c.url)
+	}
+
+	var doc jwksDocument
+	if err := json.NewDecoder(res.Body).Decode(&doc); err != nil {
+		return fmt.Errorf("jwks: failed to decode response: %w", err)
+	}
+
+	keys := make(map[string]interface{}, len(doc.Keys))
+	for _, k := range doc.Keys {
+		pub, err := k.publicKey()
+		if err != nil {
+			continue
+		}
+		keys[k.Kid] = pub
+	}
+
+	c.mu.Lock()
+	c.keys = keys
+	c.fetchedAt = time.Now()
+	c.mu.Unlock()
+	return nil
+}
+
+// publicKey decodes k into a crypto/rsa or crypto/ecdsa public key,
+// depending on its "kty".
+func (k jwk) publicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		nBytes, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("jwks: invalid modulus: %w", err)
+		}
+		eBytes, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("jwks: invalid exponent: %w", err)
+		}
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(nBytes),
+			E: int(new(big.Int).SetBytes(eBytes).Int64()),
+		}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("jwks: unsupported curve %q", k.Crv)
+		}
+		xBytes, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("jwks: invalid x coordinate: %w", err)
+		}
+		yBytes, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("jwks: invalid y coordinate: %w", err)
+		}
+		return &ecdsa.PublicKey{
+			Curve: curve,
+			X:     new(big.Int).SetBytes(xBytes),
+			Y:     new(big.Int).SetBytes(yBytes),
+		}, nil
+	default:
+		return nil, fmt.Errorf("jwks: unsupported key type %q", k.Kty)
+	}
+}
@@ -3,9 +3,19 @@ package middlewares
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog/log"
+	"net/http"
+	"strings"
 	"time"
 )
 
+// LoggerConfig configures the Logger middleware.
+type LoggerConfig struct {
+	// SlowRequestThreshold marks a request's log line with
+	// slow_request=true once its duration exceeds this value. Zero
+	// disables the check.
+	SlowRequestThreshold time.Duration `mapstructure:"slow_request_threshold"`
+}
+
 // Logger returns a gin middleware that logs the request details
 //
 // It uses the X-Request-ID header value to associate the log entries with the request.
@@ -17,9 +27,15 @@ import (
 //   - The request method
 //   - The request path
 //   - The request ID
-//   - The response status code
-//   - The time taken to process the request
-func Logger() gin.HandlerFunc {
+//   - The client IP and user agent
+//   - The response status code and size, and the request's size
+//   - The time taken to process the request, flagged slow_request when it
+//     exceeds cfg.SlowRequestThreshold
+//   - Any handler errors attached via c.Errors
+//
+// The status code controls the log level: 5xx logs at Error, 4xx at Warn,
+// everything else at Info.
+func Logger(cfg LoggerConfig) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
 		path := c.Request.URL.Path
@@ -40,6 +56,12 @@ func Logger() gin.HandlerFunc {
 			Logger()
 
 		c.Set("logger", logger)
+		// Attach the same logger to the request's context.Context (zerolog's
+		// own WithContext/Ctx idiom) so code that only has a context, not a
+		// *gin.Context — e.g. the retrytransport.RoundTripper wired into the
+		// Elasticsearch client — can still emit log lines correlated with
+		// this request's request_id.
+		c.Request = c.Request.WithContext(logger.WithContext(c.Request.Context()))
 
 		// Process the request
 		c.Next()
@@ -48,10 +70,34 @@ func Logger() gin.HandlerFunc {
 		duration := time.Since(start)
 		status := c.Writer.Status()
 
-		// Log the request details with Request-ID
-		log.Info().
+		event := logger.Info()
+		switch {
+		case status >= http.StatusInternalServerError:
+			event = logger.Error()
+		case status >= http.StatusBadRequest:
+			event = logger.Warn()
+		}
+
+		event = event.
 			Int("status", status).
 			Dur("duration", duration).
-			Msg("request processed")
+			Str("client_ip", c.ClientIP()).
+			Str("user_agent", c.Request.UserAgent()).
+			Int64("bytes_in", c.Request.ContentLength).
+			Int("bytes_out", c.Writer.Size())
+
+		if cfg.SlowRequestThreshold > 0 && duration > cfg.SlowRequestThreshold {
+			event = event.Bool("slow_request", true)
+		}
+
+		if len(c.Errors) > 0 {
+			errs := make([]string, 0, len(c.Errors))
+			for _, e := range c.Errors {
+				errs = append(errs, e.Error())
+			}
+			event = event.Str("error", strings.Join(errs, "; "))
+		}
+
+		event.Msg("request processed")
 	}
 }
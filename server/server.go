@@ -7,8 +7,11 @@ import (
 	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"go-es/internal/notify"
 	documentsHandler "go-es/internal/service/document/handler"
+	"go-es/internal/service/health"
 	indicesHandler "go-es/internal/service/index/handler"
+	percolatorHandler "go-es/internal/service/percolator/handler"
 	middlewares "go-es/server/middleware"
 	"log"
 	"net/http"
@@ -18,10 +21,11 @@ import (
 
 // Config struct with validation tags
 type Config struct {
-	BasePath string     `mapstructure:"base_path" validate:"required"`            // Must not be empty
-	Port     int        `mapstructure:"port" validate:"required,min=1,max=65535"` // Must be between 1 and 65535
-	Secret   string     `mapstructure:"api_secret"`
-	Cors     CorsConfig `mapstructure:"cors"`
+	BasePath             string                 `mapstructure:"base_path" validate:"required"`            // Must not be empty
+	Port                 int                    `mapstructure:"port" validate:"required,min=1,max=65535"` // Must be between 1 and 65535
+	Auth                 middlewares.AuthConfig `mapstructure:"auth"`
+	Cors                 CorsConfig             `mapstructure:"cors"`
+	SlowRequestThreshold time.Duration          `mapstructure:"slow_request_threshold"` // Access log entries above this duration are flagged slow_request; zero disables the check
 }
 
 // CorsConfig defines the configuration for CORS (Cross-Origin Resource Sharing).
@@ -57,7 +61,7 @@ func NewServer(cfg *Config, esc *elasticsearch.Client) Server {
 	r := gin.New()
 	r.Use(gin.Recovery())
 	r.Use(middlewares.RequestID())
-	r.Use(middlewares.Logger())
+	r.Use(middlewares.Logger(middlewares.LoggerConfig{SlowRequestThreshold: cfg.SlowRequestThreshold}))
 
 	if srv.cfg.Cors.CorsEnable {
 		corsCfg := cors.Config{
@@ -69,8 +73,8 @@ func NewServer(cfg *Config, esc *elasticsearch.Client) Server {
 		r.Use(cors.New(corsCfg))
 	}
 
-	if srv.cfg.Secret != "" {
-		r.Use(middlewares.Auth(srv.cfg.Secret))
+	if srv.cfg.Auth.Enabled() {
+		r.Use(middlewares.Auth(srv.cfg.Auth))
 	}
 
 	basePath := srv.cfg.BasePath
@@ -87,23 +91,79 @@ func NewServer(cfg *Config, esc *elasticsearch.Client) Server {
 		indices.GET("/:alias/exists", indicesHandler.Exists(srv.esc))  // Check if index exists
 		indices.GET("/:alias/info", indicesHandler.GetIndex(srv.esc))  // Get index information
 		indices.PUT("/:alias", indicesHandler.UpdateIndex(srv.esc))
+		indices.POST("/:alias/restore", indicesHandler.RestoreIndex(srv.esc))                     // Re-attach alias to a tombstoned index before its grace period expires
+		indices.POST("/:alias/reindex", indicesHandler.StartReindex(srv.esc))                     // Start a zero-downtime reindex
+		indices.GET("/:alias/reindex/:taskID", indicesHandler.GetTaskStatus(srv.esc))             // Poll reindex task progress
+		indices.DELETE("/:alias/reindex/:taskID", indicesHandler.CancelTask(srv.esc))             // Cancel a running reindex task
+		indices.POST("/:alias/reindex/:taskID/swap", indicesHandler.SwapReindexAlias(srv.esc))    // Swap the alias once reindex completes
+		indices.POST("/:alias/reindex/:taskID/rollback", indicesHandler.RollbackReindex(srv.esc)) // Cancel the task and delete its destination index
+		indices.GET("/:alias/reindex-status", indicesHandler.GetReindexStatusByAlias(srv.esc))    // Poll the background reindex job started by UpdateIndex
 	}
 
+	// Group routes for the always-reindex rollover subsystem: unlike
+	// UpdateIndex, which only reindexes when an in-place mapping update
+	// fails, Rollover always builds a new index behind the alias.
+	rollover := r.Group(basePath + "rollover")
+	{
+		rollover.POST("/:alias", indicesHandler.Rollover(srv.esc))            // Start a rollover onto a new <alias>-<timestamp> index
+		rollover.GET("/:taskID", indicesHandler.GetTaskStatus(srv.esc))       // Poll rollover task progress
+		rollover.POST("/:alias/abort", indicesHandler.AbortRollover(srv.esc)) // Cancel an in-flight rollover and delete its destination index
+	}
+
+	// Group routes for generic Elasticsearch task management
+	tasks := r.Group(basePath + "_tasks")
+	{
+		tasks.GET("/:taskID", indicesHandler.GetTaskStatus(srv.esc))       // Poll any Elasticsearch task's status
+		tasks.POST("/:taskID/_cancel", indicesHandler.CancelTask(srv.esc)) // Request cancellation of a running task
+	}
+
+	// Group routes for cluster-level information
+	cluster := r.Group(basePath + "_cluster")
+	{
+		cluster.GET("/version", indicesHandler.GetClusterVersion(srv.esc)) // Report the detected Elasticsearch cluster version
+		cluster.GET("/nodes", indicesHandler.GetClusterNodes())            // Report the currently reachable cluster nodes
+	}
+
+	// Prometheus-scrapeable transport metrics: retries, circuit breakers, per-node latency
+	r.GET(basePath+"metrics", indicesHandler.Metrics())
+	// Prometheus-scrapeable cluster health metrics
+	r.GET(basePath+"health-metrics", health.Metrics())
+
+	// Liveness/readiness probes backed by the periodic cluster health checker
+	r.GET(basePath+"readyz", health.Readyz())
+	r.GET(basePath+"livez", health.Livez())
+
 	// Group routes for documents management
 	documents := r.Group(basePath + "documents")
 	{
-		documents.POST("/:alias/add", documentsHandler.AddData(srv.esc))            // Add documentsHandler (bulk or single)
-		documents.POST("/:alias/search", documentsHandler.Search(srv.esc))          // Search documentsHandler
-		documents.POST("/:alias/suggest", documentsHandler.AutoComplete(srv.esc))   // Get document suggestions
-		documents.POST("/:alias/export", documentsHandler.ExportDocuments(srv.esc)) // Export documents
-		documents.POST("/:alias/import", documentsHandler.ImportDocuments(srv.esc)) // Import documents
-		documents.DELETE("/:alias/:id", documentsHandler.DeleteDocument(srv.esc))   // Delete document by ID
-		documents.PUT("/:alias/:id", documentsHandler.UpdateDocument(srv.esc))      // Update document by ID
-		documents.GET("/:alias", documentsHandler.ListAllDocuments(srv.esc))        // List all documentsHandler in index
-		documents.GET("/:alias/:id", documentsHandler.GetDocumentByID(srv.esc))     // Get document by ID
+		documents.POST("/:alias/add", documentsHandler.AddData(srv.esc, notify.Default()))            // Add documentsHandler (bulk or single)
+		documents.POST("/:alias/bulk", documentsHandler.BulkIngestData(srv.esc))                      // Bulk index/create/update/delete via esutil.BulkIndexer
+		documents.POST("/:alias/bulk-index", documentsHandler.BulkIndex(srv.esc))                     // Bulk-index a flat JSON array of documents via esutil.BulkIndexer
+		documents.POST("/:alias/bulk-async", documentsHandler.BulkAPISubmit(srv.esc))                 // Queue bulk items onto the shared background BulkAPI worker
+		documents.POST("/:alias/search", documentsHandler.Search(srv.esc))                            // Search documentsHandler
+		documents.POST("/:alias/_scroll", documentsHandler.SearchScroll(srv.esc))                     // Start or advance a scroll context for deep pagination
+		documents.POST("/:alias/suggest", documentsHandler.AutoComplete(srv.esc))                     // Get document suggestions
+		documents.POST("/:alias/export", documentsHandler.ExportDocuments(srv.esc))                   // Export documents
+		documents.POST("/:alias/import", documentsHandler.ImportDocuments(srv.esc, notify.Default())) // Import documents
+		documents.DELETE("/:alias/:id", documentsHandler.DeleteDocument(srv.esc, notify.Default()))   // Delete document by ID
+		documents.PUT("/:alias/:id", documentsHandler.UpdateDocument(srv.esc, notify.Default()))      // Update document by ID
+		documents.GET("/:alias", documentsHandler.ListAllDocuments(srv.esc))                          // List all documentsHandler in index
+		documents.GET("/:alias/:id", documentsHandler.GetDocumentByID(srv.esc))                       // Get document by ID
 
 	}
 
+	// Group routes for percolator (saved-query matching) management
+	percolator := r.Group(basePath + "percolator")
+	{
+		percolator.POST("/:alias", percolatorHandler.RegisterQuery(srv.esc))        // Save a query under alias
+		percolator.DELETE("/:alias/:name", percolatorHandler.DeleteQuery(srv.esc))  // Remove a saved query
+		percolator.POST("/:alias/_percolate", percolatorHandler.Percolate(srv.esc)) // Match a document against alias's saved queries
+	}
+
+	// Background sweeper for soft-deleted indices: physically deletes a
+	// tombstoned index once its grace period (set by DeleteIndex) elapses.
+	go indicesHandler.RunTombstoneSweeper(context.Background(), srv.esc)
+
 	srv.srv = &http.Server{
 		Addr:              fmt.Sprintf(":%d", srv.cfg.Port),
 		Handler:           r,